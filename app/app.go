@@ -30,6 +30,8 @@ import (
 	"github.com/essentialkaos/ek/v13/usage/man"
 	"github.com/essentialkaos/ek/v13/usage/update"
 
+	"github.com/essentialkaos/artefactor/data"
+	"github.com/essentialkaos/artefactor/feed"
 	"github.com/essentialkaos/artefactor/github"
 )
 
@@ -46,14 +48,29 @@ const (
 
 // Options
 const (
-	OPT_SOURCES  = "s:sources"
-	OPT_NAME     = "n:name"
-	OPT_TOKEN    = "t:token"
-	OPT_INSTALL  = "I:install"
-	OPT_UNIT     = "u:unit"
-	OPT_NO_COLOR = "nc:no-color"
-	OPT_HELP     = "h:help"
-	OPT_VER      = "v:version"
+	OPT_SOURCES           = "s:sources"
+	OPT_NAME              = "n:name"
+	OPT_TOKEN             = "t:token"
+	OPT_SOURCES_TOKEN     = "sources-token"
+	OPT_ALLOW_REMOTE_EXEC = "allow-remote-exec"
+	OPT_INSTALL           = "I:install"
+	OPT_ARCH              = "a:arch"
+	OPT_SIGN_KEY          = "K:sign-key"
+	OPT_PUBLIC_KEY        = "P:public-key"
+	OPT_LISTEN            = "L:listen"
+	OPT_HTML              = "H:html"
+	OPT_URL               = "U:url"
+	OPT_FEED_SIZE         = "feed-size"
+	OPT_CHANNEL           = "C:channel"
+	OPT_PROMOTE_AFTER     = "promote-after"
+	OPT_FORMAT            = "f:format"
+	OPT_LATEST_ONLY       = "l:latest-only"
+	OPT_SINCE             = "since"
+	OPT_ONLINE            = "O:online"
+	OPT_UNIT              = "u:unit"
+	OPT_NO_COLOR          = "nc:no-color"
+	OPT_HELP              = "h:help"
+	OPT_VER               = "v:version"
 
 	OPT_VERB_VER     = "vv:verbose-version"
 	OPT_COMPLETION   = "completion"
@@ -63,23 +80,51 @@ const (
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 const (
-	CMD_DOWNLOAD = "download"
-	CMD_GET      = "get"
-	CMD_LIST     = "list"
-	CMD_CLEANUP  = "cleanup"
+	CMD_DOWNLOAD  = "download"
+	CMD_GET       = "get"
+	CMD_LIST      = "list"
+	CMD_CLEANUP   = "cleanup"
+	CMD_AUDIT     = "audit"
+	CMD_VALIDATE  = "validate"
+	CMD_LINT      = "lint"
+	CMD_ADD       = "add"
+	CMD_CHANGELOG = "changelog"
+	CMD_REINDEX   = "reindex"
+	CMD_SERVE     = "serve"
+	CMD_RENDER    = "render"
+	CMD_PROMOTE   = "promote"
+	CMD_PIN       = "pin"
+	CMD_UNPIN     = "unpin"
+	CMD_ROLLBACK  = "rollback"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // optMap contains information about all supported options
 var optMap = options.Map{
-	OPT_SOURCES:  {Value: "artefacts.yml"},
-	OPT_TOKEN:    {},
-	OPT_INSTALL:  {Type: options.BOOL},
-	OPT_UNIT:     {Type: options.BOOL},
-	OPT_NO_COLOR: {Type: options.BOOL},
-	OPT_HELP:     {Type: options.BOOL},
-	OPT_VER:      {Type: options.MIXED},
+	OPT_SOURCES:           {Value: "artefacts.yml", Mergeble: true},
+	OPT_NAME:              {},
+	OPT_TOKEN:             {},
+	OPT_SOURCES_TOKEN:     {},
+	OPT_ALLOW_REMOTE_EXEC: {Type: options.BOOL},
+	OPT_ARCH:              {Value: "x86_64"},
+	OPT_SIGN_KEY:          {},
+	OPT_PUBLIC_KEY:        {},
+	OPT_LISTEN:            {Value: ":8080"},
+	OPT_HTML:              {Type: options.BOOL},
+	OPT_URL:               {},
+	OPT_FEED_SIZE:         {Type: options.INT, Value: feed.DEFAULT_SIZE, Min: 1},
+	OPT_CHANNEL:           {},
+	OPT_PROMOTE_AFTER:     {Type: options.INT, Min: 0},
+	OPT_FORMAT:            {},
+	OPT_LATEST_ONLY:       {Type: options.BOOL},
+	OPT_SINCE:             {},
+	OPT_INSTALL:           {Type: options.BOOL},
+	OPT_ONLINE:            {Type: options.BOOL},
+	OPT_UNIT:              {Type: options.BOOL},
+	OPT_NO_COLOR:          {Type: options.BOOL},
+	OPT_HELP:              {Type: options.BOOL},
+	OPT_VER:               {Type: options.MIXED},
 
 	OPT_VERB_VER:     {Type: options.BOOL},
 	OPT_COMPLETION:   {},
@@ -106,6 +151,7 @@ func Run(gitRev string, gomod []byte) {
 	configureUI()
 
 	github.Token = strutil.Q(options.GetS(OPT_TOKEN), os.Getenv("GITHUB_TOKEN"))
+	data.SourcesToken = strutil.Q(options.GetS(OPT_SOURCES_TOKEN), os.Getenv("ARTEFACTOR_SOURCES_TOKEN"))
 
 	switch {
 	case options.Has(OPT_COMPLETION):
@@ -205,6 +251,28 @@ func execCommand(args options.Arguments) error {
 		err = cmdList(args)
 	case CMD_CLEANUP:
 		err = cmdCleanup(args)
+	case CMD_AUDIT:
+		err = cmdAudit(args)
+	case CMD_VALIDATE, CMD_LINT:
+		err = cmdValidate(args)
+	case CMD_ADD:
+		err = cmdAdd(args)
+	case CMD_CHANGELOG:
+		err = cmdChangelog(args)
+	case CMD_REINDEX:
+		err = cmdReindex(args)
+	case CMD_SERVE:
+		err = cmdServe(args)
+	case CMD_RENDER:
+		err = cmdRender(args)
+	case CMD_PROMOTE:
+		err = cmdPromote(args)
+	case CMD_PIN:
+		err = cmdPin(args)
+	case CMD_UNPIN:
+		err = cmdUnpin(args)
+	case CMD_ROLLBACK:
+		err = cmdRollback(args)
 	default:
 		return fmt.Errorf("Unknown command %q", cmd)
 	}
@@ -291,13 +359,41 @@ func genUsage() *usage.Info {
 	}
 
 	info.AddCommand(CMD_DOWNLOAD, "Download and store artefacts", "dir", "?artefact")
-	info.AddCommand(CMD_LIST, "List artefacts", "dir/storage")
+	info.AddCommand(CMD_LIST, "List artefacts", "dir/storage", "?name-pattern")
 	info.AddCommand(CMD_GET, "Download artefact", "storage", "name", "?version")
+	info.AddCommand(CMD_CHANGELOG, "Show release notes", "dir/storage", "name", "?from", "?to")
 	info.AddCommand(CMD_CLEANUP, "Remove outdated artefacts", "dir", "min-versions")
-
-	info.AddOption(OPT_SOURCES, "Path to YAML file with sources {s-}(default: artefacts.yml){!}", "file")
+	info.AddCommand(CMD_PROMOTE, "Promote artefact version to release channel", "dir", "name", "version", "?channel")
+	info.AddCommand(CMD_PIN, "Pin artefact to given version", "dir", "name", "version", "?reason")
+	info.AddCommand(CMD_UNPIN, "Unpin artefact", "dir", "name")
+	info.AddCommand(CMD_ROLLBACK, "Pin artefact to the previous version", "dir", "name", "?reason")
+	info.AddCommand(CMD_REINDEX, "Rebuild index from scratch", "dir")
+	info.AddCommand(CMD_SERVE, "Serve data directory over HTTP", "dir")
+	info.AddCommand(CMD_RENDER, "Render HTML catalog of artefacts", "dir")
+	info.AddCommand(CMD_ADD, "Add artefact from repository to sources file", "repo")
+	info.AddCommand(CMD_VALIDATE, "Validate sources files", "?file")
+	info.AddCommand(CMD_LINT, "Alias for validate command", "?file")
+	info.AddCommand(CMD_AUDIT, "Check artefacts for vulnerable Go modules", "dir", "osv-db")
+
+	info.AddOption(OPT_SOURCES, "Path or URL to YAML file or directory with sources {s-}(default: artefacts.yml){!}", "file")
 	info.AddOption(OPT_TOKEN, "GitHub personal token", "token")
+	info.AddOption(OPT_SOURCES_TOKEN, "Bearer token for remote sources files", "token")
+	info.AddOption(OPT_ALLOW_REMOTE_EXEC, "Allow build recipes and smoke tests from remote sources files")
+	info.AddOption(OPT_NAME, "Artefact name", "name")
+	info.AddOption(OPT_ARCH, "Artefact architecture {s-}(default: x86_64){!}", "arch")
+	info.AddOption(OPT_LISTEN, "Address for HTTP server {s-}(default: :8080){!}", "address")
+	info.AddOption(OPT_CHANNEL, "Release channel of artefact", "channel")
+	info.AddOption(OPT_PROMOTE_AFTER, "Promote beta versions to stable after given number of days", "days")
+	info.AddOption(OPT_FORMAT, "Output format for list {s-}(json/yaml/tsv){!}", "format")
+	info.AddOption(OPT_LATEST_ONLY, "List only the latest versions of artefacts")
+	info.AddOption(OPT_SINCE, "List only versions published after given date or period {s-}(2006-01-02/30d/2w){!}", "date")
+	info.AddOption(OPT_HTML, "Render HTML catalog after updating index")
+	info.AddOption(OPT_URL, "Public URL of storage used in catalog and feed", "url")
+	info.AddOption(OPT_FEED_SIZE, "Maximum number of entries in feed {s-}(default: 50){!}", "num")
+	info.AddOption(OPT_SIGN_KEY, "Path to ed25519 private key for signing index", "file")
+	info.AddOption(OPT_PUBLIC_KEY, "Path to ed25519 public key or base64-encoded key for index verification", "key")
 	info.AddOption(OPT_INSTALL, "Install artefact to user binaries {s-}($HOME/.bin){!}")
+	info.AddOption(OPT_ONLINE, "Check sources against the latest releases on GitHub")
 	info.AddOption(OPT_UNIT, "Run application in unit mode {s-}(no colors and animations){!}")
 	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
 	info.AddOption(OPT_HELP, "Show this help message")
@@ -313,11 +409,36 @@ func genUsage() *usage.Info {
 		`Download artefacts from given file to "data" directory`,
 	)
 
+	info.AddExample(
+		"download data --sources ~/sources.d --sources ~/extra.yml",
+		`Download artefacts from all files in directory and given file to "data" directory`,
+	)
+
+	info.AddExample(
+		"download data --sources https://example.com/artefacts.yml",
+		`Download artefacts from remote sources file to "data" directory`,
+	)
+
+	info.AddExample(
+		"download data --sign-key ~/.keys/index.pem",
+		`Download artefacts to "data" directory and sign index with given key`,
+	)
+
 	info.AddExample(
 		"download data --name shellcheck",
 		`Download shellcheck artefacts to data directory`,
 	)
 
+	info.AddExample(
+		"add junegunn/fzf --arch aarch64 --name fzf-arm",
+		`Add aarch64 build of fzf from GitHub to sources file`,
+	)
+
+	info.AddExample(
+		"validate --sources ~/sources.d --online",
+		`Validate all sources files in directory and check them against GitHub releases`,
+	)
+
 	info.AddExample(
 		"list data",
 		`List all artefacts in "data" directory`,
@@ -328,11 +449,51 @@ func genUsage() *usage.Info {
 		`List all artefacts on remote storage`,
 	)
 
+	info.AddExample(
+		"list data 'go*' --latest-only --format json",
+		`Print info about the latest versions of artefacts with names starting with "go" as JSON`,
+	)
+
+	info.AddExample(
+		"list my.artefacts.storage --since 30d --format tsv",
+		`Print info about versions published in the last 30 days as TSV`,
+	)
+
+	info.AddExample(
+		"changelog my.artefacts.storage myapp 1.0.0",
+		`Show release notes of all myapp versions released after 1.0.0`,
+	)
+
 	info.AddExample(
 		"cleanup data 10",
 		`Cleanup artefacts versions in "data" directory except the last 10`,
 	)
 
+	info.AddExample(
+		"pin data myapp 1.0.0 'Broken config parsing in 1.1.0'",
+		`Pin myapp in "data" directory to version 1.0.0`,
+	)
+
+	info.AddExample(
+		"rollback data myapp",
+		`Pin myapp in "data" directory to the version before the current latest`,
+	)
+
+	info.AddExample(
+		"audit data ~/vulndb",
+		`Check Go modules in artefacts from "data" directory using local OSV database`,
+	)
+
+	info.AddExample(
+		"render data --url my.artefacts.storage",
+		`Render HTML catalog of artefacts in "data" directory`,
+	)
+
+	info.AddExample(
+		"serve data --listen 127.0.0.1:9000",
+		`Serve "data" directory on port 9000`,
+	)
+
 	info.AddExample(
 		"get my.artefacts.storage myapp",
 		`Download the latest version of myapp files from remote storage`,
@@ -343,6 +504,16 @@ func genUsage() *usage.Info {
 		`Download myapp version 1.0.0 files from remote storage`,
 	)
 
+	info.AddExample(
+		"get my.artefacts.storage myapp --channel stable",
+		`Download version of myapp from stable channel`,
+	)
+
+	info.AddExample(
+		"get my.artefacts.storage myapp --public-key ~/.keys/index.pub",
+		`Download myapp files from remote storage verifying index signature`,
+	)
+
 	return info
 }
 
@@ -0,0 +1,287 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/httputil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/spinner"
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/npck"
+
+	"github.com/essentialkaos/artefactor/binary"
+	"github.com/essentialkaos/artefactor/data"
+	"github.com/essentialkaos/artefactor/github"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// archTokens contains tokens used in asset names for different architectures
+var archTokens = map[string][]string{
+	"x86_64":  {"x86_64", "amd64", "x64", "linux64", "64bit"},
+	"aarch64": {"aarch64", "arm64"},
+	"i386":    {"i386", "i686", "386", "32bit"},
+	"arm":     {"armv7", "armv6", "armhf"},
+}
+
+// ignoredAssetSuffixes contains suffixes of assets which can't be artefacts
+var ignoredAssetSuffixes = []string{
+	".sha256", ".sha512", ".sha256sum", ".sha512sum", ".md5", ".sig", ".asc",
+	".pem", ".crt", ".sbom", ".spdx", ".json", ".txt", ".intoto.jsonl",
+	".deb", ".rpm", ".apk", ".msi", ".exe", ".dmg", ".pkg",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdAdd is "add" command handler
+func cmdAdd(args options.Arguments) error {
+	if !args.Has(0) {
+		return fmt.Errorf("You must provide repository name (owner/repo)")
+	}
+
+	repo := args.Get(0).String()
+
+	if strings.Count(repo, "/") != 1 {
+		return fmt.Errorf("Repository name %q is invalid", repo)
+	}
+
+	sourcesFile, err := getSourcesFileForAdd(repo)
+
+	if err != nil {
+		return err
+	}
+
+	artefact := &data.Artefact{
+		Name:     strutil.Q(options.GetS(OPT_NAME), path.Base(repo)),
+		Repo:     repo,
+		Provider: data.PROVIDER_RELEASES,
+		Arch:     binary.NormalizeArch(options.GetS(OPT_ARCH)),
+		OS:       data.DEFAULT_OS,
+	}
+
+	artefact.Output = artefact.Name + "-" + artefact.Arch
+
+	err = scaffoldArtefact(artefact)
+
+	if err != nil {
+		return err
+	}
+
+	err = artefact.Validate()
+
+	if err != nil {
+		return err
+	}
+
+	err = data.AppendArtefact(sourcesFile, artefact)
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn("{g}Artefact {*}%s{!*} added to %s{!}", artefact.Name, sourcesFile)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getSourcesFileForAdd returns path to file which must be used for adding new
+// artefact
+func getSourcesFileForAdd(repo string) (string, error) {
+	sources := options.Split(OPT_SOURCES)
+	sourcesFile := sources[0]
+
+	if httputil.IsURL(sourcesFile) {
+		return "", fmt.Errorf("Can't add artefact to remote sources file %s", sourcesFile)
+	}
+
+	if fsutil.IsDir(sourcesFile) {
+		sourcesFile = path.Join(sourcesFile, strutil.Q(options.GetS(OPT_NAME), path.Base(repo))+".yml")
+	}
+
+	if !fsutil.IsExist(sourcesFile) {
+		return sourcesFile, nil
+	}
+
+	artefacts, err := data.ReadArtefacts(sources...)
+
+	if err != nil {
+		return "", err
+	}
+
+	name := strutil.Q(options.GetS(OPT_NAME), path.Base(repo))
+
+	for _, artefact := range artefacts {
+		if artefact.Name == name {
+			return "", fmt.Errorf("Artefact %q is already defined in %s", name, artefact.Position())
+		}
+	}
+
+	return sourcesFile, nil
+}
+
+// scaffoldArtefact fills artefact source and file using info about the
+// latest release
+func scaffoldArtefact(artefact *data.Artefact) error {
+	spinner.Show("Fetching info about the latest release of %s", artefact.Repo)
+	release, err := github.GetLatestReleaseInfo(artefact.Repo)
+	spinner.Done(err == nil)
+
+	if err != nil {
+		return err
+	}
+
+	version := strings.TrimLeft(release.Version, "v")
+	asset := findBestAsset(release.Assets, artefact.Arch, artefact.OS)
+
+	if asset == nil {
+		return fmt.Errorf(
+			"Can't find asset for %s/%s in release %s",
+			artefact.OS, artefact.Arch, release.Version,
+		)
+	}
+
+	fmtc.Printfn("   Found asset: {*}%s{!}", asset.Name)
+
+	artefact.Source = asset.Name
+
+	// Empty version would put * between every character of asset name
+	if version != "" {
+		artefact.Source = strings.ReplaceAll(asset.Name, version, "*")
+	}
+
+	if !isArchive(artefact) {
+		return nil
+	}
+
+	spinner.Show("Downloading %s", asset.Name)
+	file, err := downloadTempFile(asset.URL, artefact.Name+getArtefactExt(artefact))
+	spinner.Done(err == nil)
+
+	if err != nil {
+		return err
+	}
+
+	binFile, err := findBinaryInArchive(file, artefact.Name, path.Base(artefact.Repo))
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn("   Found binary: {*}%s{!}", binFile)
+
+	artefact.File = binFile
+
+	if version != "" {
+		artefact.File = strings.ReplaceAll(binFile, version, "{version}")
+	}
+
+	return nil
+}
+
+// findBestAsset returns the most suitable asset for given arch and OS
+func findBestAsset(assets []*github.Asset, arch, os string) *github.Asset {
+	var result *github.Asset
+	var bestScore int
+
+	for _, asset := range assets {
+		score := getAssetScore(strings.ToLower(asset.Name), arch, os)
+
+		if score > bestScore {
+			result, bestScore = asset, score
+		}
+	}
+
+	return result
+}
+
+// getAssetScore returns score of asset for given arch and OS
+func getAssetScore(name, arch, os string) int {
+	for _, suffix := range ignoredAssetSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return 0
+		}
+	}
+
+	if !strings.Contains(name, os) {
+		return 0
+	}
+
+	score := 1
+
+	switch {
+	case slices.ContainsFunc(archTokens[arch], func(t string) bool { return strings.Contains(name, t) }):
+		score += 10
+	case !strings.Contains(name, arch):
+		return 0
+	}
+
+	switch {
+	case strings.Contains(name, "musl"), strings.Contains(name, "static"):
+		score += 3
+	case strings.Contains(name, "gnu"):
+		score += 1
+	}
+
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		score += 2
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".zip"):
+		score += 1
+	}
+
+	return score
+}
+
+// findBinaryInArchive unpacks archive and returns path to binary inside it
+func findBinaryInArchive(file string, names ...string) (string, error) {
+	spinner.Show("Unpacking data")
+
+	tmpDir, err := temp.MkDir()
+
+	if err != nil {
+		spinner.Done(false)
+		return "", err
+	}
+
+	err = npck.Unpack(file, tmpDir)
+
+	if err != nil {
+		spinner.Done(false)
+		return "", fmt.Errorf("Can't unpack data: %v", err)
+	}
+
+	spinner.Done(true)
+
+	files := fsutil.ListAllFiles(tmpDir, true)
+
+	for _, name := range names {
+		for _, file := range files {
+			if path.Base(file) == name {
+				return file, nil
+			}
+		}
+	}
+
+	for _, file := range files {
+		if binary.IsELF(path.Join(tmpDir, file)) {
+			return file, nil
+		}
+	}
+
+	return "", fmt.Errorf("Can't find binary in archive, you have to define file manually")
+}
@@ -0,0 +1,173 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/spinner"
+	"github.com/essentialkaos/ek/v13/terminal"
+
+	"github.com/essentialkaos/artefactor/binary"
+	"github.com/essentialkaos/artefactor/data"
+	"github.com/essentialkaos/artefactor/osv"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdAudit is "audit" command handler
+func cmdAudit(args options.Arguments) error {
+	switch {
+	case !args.Has(0):
+		return fmt.Errorf("You must provide path to data directory")
+	case !args.Has(1):
+		return fmt.Errorf("You must provide path to OSV database directory")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+	index, err := readLocalIndex(dataDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't get index data: %v", err)
+	} else if index.IsEmpty() {
+		terminal.Warn("No artefacts found")
+		return nil
+	}
+
+	spinner.Show("Loading vulnerability database")
+	db, err := osv.Load(args.Get(1).Clean().String())
+	spinner.Done(err == nil)
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.NewLine()
+
+	vulnVersions := auditArtefacts(index, dataDir, db)
+
+	if vulnVersions != 0 {
+		return fmt.Errorf(
+			"Found %s artefact versions with vulnerable modules",
+			fmtutil.PrettyNum(vulnVersions),
+		)
+	}
+
+	fmtc.Println("{g}No vulnerable modules found in stored artefacts{!}")
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// auditArtefacts checks all versions of artefacts and returns number of
+// vulnerable versions
+func auditArtefacts(index *data.Index, dataDir string, db *osv.DB) int {
+	var vulnVersions int
+
+	for _, info := range index.Artefacts {
+		for _, version := range info.Versions {
+			versionDir := path.Join(dataDir, info.Name, version.Version)
+
+			if auditArtefactVersion(info.Name, versionDir, version, db) {
+				vulnVersions++
+			}
+		}
+	}
+
+	return vulnVersions
+}
+
+// auditArtefactVersion checks modules of all version binaries and returns
+// true if some of them are vulnerable
+func auditArtefactVersion(name, versionDir string, version *data.ArtefactVersion, db *osv.DB) bool {
+	var isVulnerable bool
+
+	buildInfo := getVersionBuildInfo(versionDir, version)
+
+	// Sort files to keep output order stable between runs
+	for _, file := range slices.Sorted(maps.Keys(buildInfo)) {
+		for _, module := range getAuditModules(buildInfo[file]) {
+			vulns := db.Find(module.Path, module.Version)
+
+			if len(vulns) == 0 {
+				continue
+			}
+
+			if !isVulnerable {
+				fmtc.Printfn("{*}%s{!}{s}:{!}{*}%s{!}", name, version.Version)
+				isVulnerable = true
+			}
+
+			for _, vuln := range vulns {
+				fixed := vuln.Fixed(module.Path, module.Version)
+
+				fmtc.Printf(
+					" {s-}•{!} {s}%s{!} %s{s}@%s{!} {r}%s{!}",
+					file, module.Path, module.Version, vuln.ID,
+				)
+
+				if fixed != "" {
+					fmtc.Printf(" {s-}(fixed in %s){!}", fixed)
+				}
+
+				fmtc.NewLine()
+			}
+		}
+	}
+
+	if isVulnerable {
+		fmtc.NewLine()
+	}
+
+	return isVulnerable
+}
+
+// getAuditModules returns modules of binary which must be checked for
+// vulnerabilities: standard library, main module and all dependencies
+func getAuditModules(buildInfo *binary.BuildInfo) []*binary.Module {
+	modules := []*binary.Module{{Path: osv.STDLIB, Version: buildInfo.GoVersion}}
+
+	if buildInfo.Main != "" && buildInfo.MainVersion != "" {
+		modules = append(modules, &binary.Module{
+			Path:    buildInfo.Main,
+			Version: buildInfo.MainVersion,
+		})
+	}
+
+	return append(modules, buildInfo.Modules...)
+}
+
+// getVersionBuildInfo returns Go build info for all version binaries
+func getVersionBuildInfo(versionDir string, version *data.ArtefactVersion) map[string]*binary.BuildInfo {
+	meta, err := data.ReadMetadata(versionDir)
+
+	if err == nil && len(meta.Build) != 0 {
+		return meta.Build
+	}
+
+	// Versions downloaded before metadata was introduced don't have
+	// info about build, so we read it from binaries directly
+	result := map[string]*binary.BuildInfo{}
+
+	for _, file := range version.Files {
+		buildInfo, err := binary.ReadBuildInfo(path.Join(versionDir, file.Name))
+
+		if err == nil {
+			result[file.Name] = buildInfo
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,54 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/essentialkaos/artefactor/binary"
+	"github.com/essentialkaos/artefactor/osv"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestGetAuditModules(t *testing.T) {
+	deps := []*binary.Module{{Path: "golang.org/x/net", Version: "v0.20.0"}}
+
+	tests := []struct {
+		info   *binary.BuildInfo
+		result []string
+	}{
+		{
+			&binary.BuildInfo{
+				GoVersion:   "go1.22.4",
+				Main:        "helm.sh/helm/v3",
+				MainVersion: "v3.15.0",
+				Modules:     deps,
+			},
+			[]string{osv.STDLIB + "@go1.22.4", "helm.sh/helm/v3@v3.15.0", "golang.org/x/net@v0.20.0"},
+		},
+		{
+			// Metadata saved before main module version was recorded
+			&binary.BuildInfo{GoVersion: "go1.22.4", Main: "helm.sh/helm/v3", Modules: deps},
+			[]string{osv.STDLIB + "@go1.22.4", "golang.org/x/net@v0.20.0"},
+		},
+	}
+
+	for _, test := range tests {
+		var result []string
+
+		for _, module := range getAuditModules(test.info) {
+			result = append(result, module.Path+"@"+module.Version)
+		}
+
+		if !slices.Equal(result, test.result) {
+			t.Errorf("getAuditModules() = %v, want %v", result, test.result)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/pager"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/req"
+	"github.com/essentialkaos/ek/v13/terminal/tty"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdChangelog is "changelog" command handler
+func cmdChangelog(args options.Arguments) error {
+	switch {
+	case !args.Has(0):
+		return fmt.Errorf("You must provide path to data directory or URL of storage")
+	case !args.Has(1):
+		return fmt.Errorf("You must provide name of artefact")
+	}
+
+	index, err := readIndex(args.Get(0))
+
+	if err != nil {
+		return fmt.Errorf("Can't get index data: %v", err)
+	}
+
+	name := args.Get(1).String()
+	info := index.Find(name)
+
+	if info == nil {
+		return fmt.Errorf("There is no artefact %q in storage", name)
+	}
+
+	versions, err := getChangelogVersions(info, args.Get(2).String(), args.Get(3).String())
+
+	if err != nil {
+		return err
+	}
+
+	if tty.IsTTY() {
+		if pager.Setup() == nil {
+			defer pager.Complete()
+		}
+	}
+
+	for _, version := range slices.Backward(versions) {
+		meta, err := readVersionMetadata(args.Get(0), info.Name, version.Version)
+
+		if err != nil {
+			return fmt.Errorf("Can't read metadata for version %s: %v", version.Version, err)
+		}
+
+		printReleaseNotes(version.Version, meta.Release)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getChangelogVersions returns versions after "from" version up to and including
+// "to" version
+func getChangelogVersions(info *data.ArtefactInfo, from, to string) ([]*data.ArtefactVersion, error) {
+	start, end := 0, len(info.Versions)
+
+	if from != "" {
+		index := slices.IndexFunc(info.Versions, func(v *data.ArtefactVersion) bool {
+			return v.Version == from
+		})
+
+		if index == -1 {
+			return nil, fmt.Errorf("There is no version %s of %s", from, info.Name)
+		}
+
+		start = index + 1
+	}
+
+	if to != "" {
+		index := slices.IndexFunc(info.Versions, func(v *data.ArtefactVersion) bool {
+			return v.Version == to
+		})
+
+		if index == -1 {
+			return nil, fmt.Errorf("There is no version %s of %s", to, info.Name)
+		}
+
+		end = index + 1
+	}
+
+	if start >= end {
+		return nil, fmt.Errorf("There are no versions between %s and %s", from, to)
+	}
+
+	return info.Versions[start:end], nil
+}
+
+// readVersionMetadata reads metadata of artefact version from data directory or
+// remote storage
+func readVersionMetadata(source options.Argument, name, version string) (*data.Metadata, error) {
+	if fsutil.IsExist(source.Clean().String()) {
+		return data.ReadMetadata(path.Join(source.Clean().String(), name, version))
+	}
+
+	url := source.String()
+
+	if !strings.HasPrefix(url, "http") {
+		url = "https://" + url
+	}
+
+	resp, err := req.Request{
+		URL:         url + "/" + path.Join(name, version, data.METADATA_FILE),
+		Accept:      req.CONTENT_TYPE_JSON,
+		AutoDiscard: true,
+	}.Get()
+
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("Can't send request: %v", err)
+	case resp.StatusCode == 404:
+		return &data.Metadata{}, nil
+	case resp.StatusCode != 200:
+		return nil, fmt.Errorf("Storage returned non-ok status code %d", resp.StatusCode)
+	}
+
+	meta := &data.Metadata{}
+	err = resp.JSON(meta)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode metadata: %v", err)
+	}
+
+	return meta, nil
+}
+
+// printReleaseNotes prints release notes for given version
+func printReleaseNotes(version string, release *data.ReleaseInfo) {
+	if release == nil {
+		fmtc.Printfn("{s-}┌{!}{*@} %s {!}", version)
+		fmtc.Printfn("{s-}│{!}")
+		fmtc.Printfn("{s-}└{!} {s}No release notes{!}")
+		fmtc.NewLine()
+		return
+	}
+
+	fmtc.Printfn(
+		"{s-}┌{!}{*@} %s {!}{#240}{*@} %s {!}",
+		version, timeutil.Format(release.Published, "%Y/%m/%d"),
+	)
+
+	if release.Name != "" && release.Name != release.Tag {
+		fmtc.Printfn("{s-}│{!} {*}%s{!}", release.Name)
+	}
+
+	fmtc.Printfn("{s-}│{!}")
+
+	notes := strings.TrimSpace(strings.ReplaceAll(release.Notes, "\r\n", "\n"))
+
+	if notes == "" {
+		notes = "No release notes"
+	}
+
+	// Notes are printed as is, because they can contain curly braces
+	// which can be treated as color tags
+	for _, line := range strings.Split(notes, "\n") {
+		fmtc.Print("{s-}│{!} ")
+		fmt.Println(line)
+	}
+
+	if release.URL != "" {
+		fmtc.Printfn("{s-}└{!} {s}%s{!}", release.URL)
+	} else {
+		fmtc.Printfn("{s-}└{!}")
+	}
+
+	fmtc.NewLine()
+}
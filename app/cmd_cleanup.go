@@ -58,26 +58,26 @@ func cmdCleanup(args options.Arguments) error {
 		return nil
 	}
 
-	err = cleanupVersions(index, dataDir, keepVersions)
+	removed, err := cleanupVersions(index, dataDir, keepVersions)
 
 	if err != nil {
 		return err
 	}
 
-	return rebuildIndex(dataDir)
+	return updateIndex(dataDir, nil, removed)
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// cleanupVersions removes outdated versions
-func cleanupVersions(index *data.Index, dataDir string, keepVersions int) error {
+// cleanupVersions removes outdated versions and returns map with removed versions
+func cleanupVersions(index *data.Index, dataDir string, keepVersions int) (map[string][]string, error) {
 	var versionNum int
 
 	allVersions := getVersionToRemove(index, dataDir, keepVersions)
 
 	if len(allVersions) == 0 {
 		terminal.Warn("No versions to clean")
-		return nil
+		return nil, nil
 	}
 
 	fmtc.NewLine()
@@ -116,7 +116,7 @@ func cleanupVersions(index *data.Index, dataDir string, keepVersions int) error
 
 	fmtc.NewLine()
 
-	return nil
+	return allVersions, nil
 }
 
 // getVersionToRemove returns map with info about outdated versions
@@ -129,6 +129,12 @@ func getVersionToRemove(index *data.Index, dataDir string, keepVersions int) map
 		}
 
 		for i := 0; i < len(a.Versions)-keepVersions; i++ {
+			// Versions from release channels and pinned version must be kept
+			if len(getVersionChannels(a, a.Versions[i].Version)) != 0 ||
+				(a.Pin != nil && a.Pin.Version == a.Versions[i].Version) {
+				continue
+			}
+
 			versions[a.Name] = append(versions[a.Name], a.Versions[i].Version)
 		}
 	}
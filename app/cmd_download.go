@@ -9,10 +9,20 @@ package app
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/fmtutil"
@@ -23,11 +33,15 @@ import (
 	"github.com/essentialkaos/ek/v13/req"
 	"github.com/essentialkaos/ek/v13/spinner"
 	"github.com/essentialkaos/ek/v13/strutil"
+	"github.com/essentialkaos/ek/v13/terminal"
 	"github.com/essentialkaos/ek/v13/timeutil"
 
 	"github.com/essentialkaos/npck"
 
+	"github.com/essentialkaos/artefactor/binary"
+	"github.com/essentialkaos/artefactor/catalog"
 	"github.com/essentialkaos/artefactor/data"
+	"github.com/essentialkaos/artefactor/feed"
 	"github.com/essentialkaos/artefactor/github"
 )
 
@@ -40,7 +54,7 @@ func cmdDownload(args options.Arguments) error {
 	}
 
 	dataDir := args.Get(0).Clean().String()
-	artefactName := args.Get(1).String()
+	artefactName := strutil.Q(args.Get(1).String(), options.GetS(OPT_NAME))
 
 	err := fsutil.ValidatePerms("DWRX", dataDir)
 
@@ -48,7 +62,7 @@ func cmdDownload(args options.Arguments) error {
 		return err
 	}
 
-	artefacts, err := data.ReadArtefacts(options.GetS(OPT_SOURCES))
+	artefacts, err := data.ReadArtefacts(options.Split(OPT_SOURCES)...)
 
 	if err != nil {
 		return err
@@ -69,6 +83,10 @@ func cmdDownload(args options.Arguments) error {
 func downloadArtefacts(artefacts data.Artefacts, dataDir, artefactName string) error {
 	var isFailed bool
 
+	added := map[string][]string{}
+
+	setRepoTokens(artefacts)
+
 	fmtc.NewLine()
 
 	for _, artefact := range artefacts {
@@ -76,102 +94,241 @@ func downloadArtefacts(artefacts data.Artefacts, dataDir, artefactName string) e
 			continue
 		}
 
-		err := downloadArtefact(artefact, dataDir)
+		version, err := downloadArtefact(artefact, dataDir)
+
+		if err == nil && options.GetI(OPT_PROMOTE_AFTER) > 0 {
+			var promoted string
+
+			promoted, err = autoPromoteArtefact(artefact, dataDir)
+
+			if version == "" {
+				version = promoted
+			}
+		}
 
 		if err != nil {
-			fmtc.Printfn("   {r}%v{!}", err)
+			printDownloadError(err)
 			isFailed = true
 		}
 
+		// Version can be stored even if artefact state wasn't updated
+		if version != "" {
+			added[artefact.StorageDir()] = append(added[artefact.StorageDir()], version)
+		}
+
 		temp.Clean()
 		fmtc.NewLine()
 	}
 
 	restorePermissions(dataDir)
 
+	oldIndex, _ := readLocalIndex(dataDir)
+	err := updateIndex(dataDir, added, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if len(added) != 0 {
+		err = updateFeed(dataDir, oldIndex)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	if isFailed {
 		return fmt.Errorf("Some artefacts can not be downloaded from GitHub")
 	}
 
-	return rebuildIndex(dataDir)
+	return nil
+}
+
+// printDownloadError prints error occurred while downloading artefact. Error
+// message is printed as is, because asset names in it can contain curly braces
+// which fmtc treats as color tags.
+func printDownloadError(err error) {
+	fmtc.Print("   {r}")
+	fmt.Print(err.Error())
+	fmtc.Println("{!}")
 }
 
-// downloadArtefact downloads specified artefact
-func downloadArtefact(artefact *data.Artefact, dataDir string) error {
+// downloadArtefact downloads specified artefact and returns stored version
+// (empty if there is no update)
+func downloadArtefact(artefact *data.Artefact, dataDir string) (string, error) {
 	fmtc.Printfn(
 		"{*}Downloading {c}%s{!}{*} from {s}%s{!}{*}…{!}",
 		artefact.Name, artefact.Repo,
 	)
 
+	err := checkRemoteCommands(artefact)
+
+	if err != nil {
+		return "", err
+	}
+
 	spinner.Show("Checking the latest version on GitHub")
-	version, pubDate, err := github.GetLatestReleaseVersion(artefact.Repo)
+	version, pubDate, err := getLatestVersion(artefact)
 	spinner.Done(err == nil)
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	fmtc.Printfn(
-		"   Found version: {g}%s{!} {s-}(%s){!}",
-		version, timeutil.Format(pubDate, "%Y/%m/%d %H:%M"),
-	)
+	if pubDate.IsZero() {
+		fmtc.Printfn("   Found version: {g}%s{!}", version)
+	} else {
+		fmtc.Printfn(
+			"   Found version: {g}%s{!} {s-}(%s){!}",
+			version, timeutil.Format(pubDate, "%Y/%m/%d %H:%M"),
+		)
+	}
 
 	artefact.ApplyVersion(version)
 
-	releaseDir := path.Join(dataDir, strutil.Q(artefact.Dir, artefact.Name), version)
+	releaseDir := path.Join(dataDir, artefact.StorageDir(), version)
 	outputFile := path.Join(releaseDir, artefact.Output)
 
+	if artefact.IsMirror() {
+		outputFile = path.Join(releaseDir, data.RELEASE_FILE)
+	}
+
 	if fsutil.IsExist(outputFile) {
 		modDate, err := fsutil.GetMTime(outputFile)
 
 		if err == nil && modDate.After(pubDate) {
 			fmtc.Println("   {s}There is no update available for this application{!}")
-			return nil
+			return "", setArtefactOrdering(dataDir, artefact)
 		}
 	}
 
-	err = downloadArtefactData(artefact, version, releaseDir, outputFile)
+	if artefact.IsMirror() {
+		err = mirrorArtefactData(artefact, releaseDir)
+	} else {
+		err = downloadArtefactData(artefact, version, releaseDir, outputFile)
+	}
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	latestLink := path.Join(dataDir, strutil.Q(artefact.Dir, artefact.Name), "latest")
+	binarySize := fsutil.GetSize(outputFile)
 
-	if fsutil.IsLink(latestLink) {
-		os.Remove(latestLink)
+	if artefact.IsMirror() {
+		binarySize = getDirSize(releaseDir)
 	}
 
-	if !fsutil.IsExist(latestLink) {
-		err = os.Symlink(version, latestLink)
+	fmtc.Printfn(
+		"   {g}Artefact successfully downloaded (%s) and saved to data directory{!}",
+		fmtutil.PrettySize(binarySize),
+	)
+
+	// Files already stored, so version must be added to index even if
+	// artefact state can't be updated
+	return version, updateArtefactState(dataDir, artefact, version)
+}
+
+// updateArtefactState updates versions ordering, link to the latest version
+// and beta channel of artefact after storing new version
+func updateArtefactState(dataDir string, artefact *data.Artefact, version string) error {
+	artefactDir := path.Join(dataDir, artefact.StorageDir())
+	state, err := data.ReadState(artefactDir)
+
+	if err != nil {
+		return err
+	}
+
+	if state.IsPinned() {
+		fmtc.Printfn(
+			"   {y}Artefact is pinned to version %s, so link to the latest version will not be updated{!}",
+			state.Pin.Version,
+		)
+	} else {
+		err = updateLatestLink(dataDir, artefact.StorageDir(), version)
 
 		if err != nil {
-			return fmt.Errorf("Can't create link to the latest release: %v", err)
+			return err
 		}
 	}
 
-	binarySize := fsutil.GetSize(outputFile)
+	// Re-downloading the same version must not reset beta channel update
+	// date, otherwise auto-promotion will be delayed
+	if state.Ordering == artefact.Ordering &&
+		state.GetChannel(data.CHANNEL_BETA) == version {
+		return nil
+	}
 
-	fmtc.Printfn(
-		"   {g}Artefact successfully downloaded (%s) and saved to data directory{!}",
-		fmtutil.PrettySize(binarySize),
-	)
+	state.Ordering = artefact.Ordering
+
+	if state.GetChannel(data.CHANNEL_BETA) != version {
+		state.SetChannel(data.CHANNEL_BETA, version)
+	}
+
+	err = state.Write(artefactDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't save artefact state: %v", err)
+	}
+
+	return nil
+}
+
+// checkRemoteCommands checks if artefact from remote sources file runs commands
+// and running such commands is not allowed
+func checkRemoteCommands(artefact *data.Artefact) error {
+	if !artefact.IsRemote() || options.GetB(OPT_ALLOW_REMOTE_EXEC) {
+		return nil
+	}
+
+	switch {
+	case artefact.Build != nil:
+		return fmt.Errorf("Artefact from remote sources file uses build recipe, use --allow-remote-exec to run its commands")
+	case artefact.Test != nil:
+		return fmt.Errorf("Artefact from remote sources file uses smoke test, use --allow-remote-exec to run it")
+	}
 
 	return nil
 }
 
 // downloadArtefactData downloads and stores artefact
 func downloadArtefactData(artefact *data.Artefact, version, outputDir, outputFile string) error {
-	spinner.Show("Downloading binary from GitHub")
-	binFile, err := downloadArtefactFile(artefact, version)
-	spinner.Done(err == nil)
+	var err error
+	var binFile, sourceURL, buildLog string
 
-	if err != nil {
-		return err
+	if artefact.Build != nil {
+		binFile, buildLog, err = buildArtefact(artefact)
+
+		if err != nil {
+			return err
+		}
+	} else {
+		spinner.Show("Downloading binary from GitHub")
+		binFile, sourceURL, err = downloadArtefactFile(artefact, version)
+		spinner.Done(err == nil)
+
+		if err != nil {
+			return err
+		}
+
+		if isArchive(artefact) && artefact.File != "" {
+			binFile, err = unpackArtefactArchive(artefact, binFile)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if artefact.Arch != "" {
+		err = checkArtefactArch(artefact, binFile)
+
+		if err != nil {
+			return err
+		}
 	}
 
-	if isArchive(artefact) {
-		binFile, err = unpackArtefactArchive(artefact, binFile)
+	if artefact.Test != nil {
+		err = testArtefactBinary(artefact, binFile)
 
 		if err != nil {
 			return err
@@ -192,30 +349,220 @@ func downloadArtefactData(artefact *data.Artefact, version, outputDir, outputFil
 		return err
 	}
 
+	if buildLog != "" {
+		err = os.WriteFile(path.Join(outputDir, data.BUILD_LOG_FILE), []byte(buildLog), 0644)
+
+		if err != nil {
+			return fmt.Errorf("Can't save build log: %v", err)
+		}
+	}
+
+	return saveArtefactMetadata(artefact, outputDir, outputFile, getAssetName(artefact, sourceURL))
+}
+
+// mirrorArtefactData downloads and stores all matching release assets
+func mirrorArtefactData(artefact *data.Artefact, outputDir string) error {
+	release, err := github.GetLatestReleaseInfo(artefact.Repo)
+
+	if err != nil {
+		return err
+	}
+
+	var assets []*github.Asset
+
+	for _, asset := range release.Assets {
+		if artefact.IsMatchAsset(asset.Name) {
+			assets = append(assets, asset)
+		}
+	}
+
+	if len(assets) == 0 {
+		return fmt.Errorf("There are no matching assets in release %s", release.Version)
+	}
+
+	if !fsutil.IsExist(outputDir) {
+		err = os.MkdirAll(outputDir, 0755)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	spinner.Show("Downloading release assets from GitHub")
+
+	for i, asset := range assets {
+		spinner.Update(
+			"{s}[%d/%d]{!} Downloading {?primary}%s{!}",
+			i+1, len(assets), asset.Name,
+		)
+
+		assetFile, err := downloadTempFile(asset.URL, path.Base(asset.Name))
+
+		if err == nil {
+			err = fsutil.CopyFile(assetFile, path.Join(outputDir, path.Base(asset.Name)), 0644)
+		}
+
+		if err != nil {
+			spinner.Done(false)
+			return fmt.Errorf("Can't download asset %s: %v", asset.Name, err)
+		}
+	}
+
+	spinner.Update("Downloaded %d release assets from GitHub", len(assets))
+	spinner.Done(true)
+
+	err = saveArtefactMetadata(artefact, outputDir, "", "")
+
+	if err != nil {
+		return err
+	}
+
+	// Release info is saved last, so it can be used as a marker of
+	// successfully mirrored release
+	err = os.WriteFile(path.Join(outputDir, data.RELEASE_FILE), release.Raw(), 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't save release info: %v", err)
+	}
+
+	return nil
+}
+
+// saveArtefactMetadata saves metadata of downloaded artefact
+func saveArtefactMetadata(artefact *data.Artefact, outputDir, outputFile, asset string) error {
+	meta, err := data.ReadMetadata(outputDir)
+
+	if err != nil {
+		return err
+	}
+
+	releaseInfo := getReleaseInfo(artefact)
+
+	if releaseInfo != nil {
+		meta.Release = releaseInfo
+	}
+
+	meta.Source = &data.SourceInfo{
+		Repo:       artefact.Repo,
+		Asset:      asset,
+		Downloaded: time.Now().UTC(),
+	}
+
+	if outputFile != "" {
+		meta.Source.File = artefact.Output
+		meta.Source.Platform = artefact.OS
+
+		if artefact.Arch != "" {
+			meta.Source.Platform += "/" + artefact.Arch
+		}
+	}
+
+	if outputFile != "" {
+		buildInfo, err := binary.ReadBuildInfo(outputFile)
+
+		if err == nil {
+			meta.SetBuildInfo(artefact.Output, buildInfo)
+		}
+	}
+
+	err = meta.Write(outputDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't save metadata: %v", err)
+	}
+
 	return nil
 }
 
-// downloadArtefactFile downloads binary file
-func downloadArtefactFile(artefact *data.Artefact, version string) (string, error) {
+// getReleaseInfo returns info about the latest upstream release of artefact
+func getReleaseInfo(artefact *data.Artefact) *data.ReleaseInfo {
+	if artefact.Provider == data.PROVIDER_ACTIONS {
+		run, err := getLatestWorkflowRun(artefact)
+
+		if err != nil {
+			return nil
+		}
+
+		return &data.ReleaseInfo{
+			Tag:       run.HeadSHA,
+			Name:      fmt.Sprintf("%s #%d (%s)", run.Name, run.Number, run.HeadBranch),
+			URL:       run.URL,
+			Published: run.UpdatedAt,
+		}
+	}
+
+	if artefact.Provider == data.PROVIDER_TAGS {
+		tag, err := github.GetLatestTag(artefact.Repo, artefact.Ordering)
+
+		if err != nil {
+			return nil
+		}
+
+		return &data.ReleaseInfo{
+			Tag: tag.Name,
+			URL: "https://github.com/" + artefact.Repo + "/tree/" + tag.Name,
+		}
+	}
+
+	release, err := github.GetLatestReleaseInfo(artefact.Repo)
+
+	if err != nil {
+		return nil
+	}
+
+	return &data.ReleaseInfo{
+		Tag:       release.Version,
+		Name:      release.Name,
+		Notes:     release.Notes,
+		URL:       release.URL,
+		Published: release.PublishDate,
+	}
+}
+
+// downloadArtefactFile downloads binary file and returns path to it and its
+// source URL
+func downloadArtefactFile(artefact *data.Artefact, version string) (string, string, error) {
 	url, err := getArtefactBinaryURL(artefact)
 
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	tempFd, tempName, err := temp.MkFile(artefact.Name + getArtefactExt(artefact))
+	file, err := downloadTempFile(url, artefact.Name+getArtefactExt(artefact))
+
+	return file, url, err
+}
+
+// downloadTempFile downloads file from given URL to temporary directory
+func downloadTempFile(url, name string) (string, error) {
+	tempFd, tempName, err := temp.MkFile(name)
 
 	if err != nil {
 		return "", err
 	}
 
+	defer tempFd.Close()
+
+	var accept string
+
+	url = github.GetAssetDownloadURL(url)
+
+	// Release assets API returns asset data only for binary content type
+	if strings.Contains(url, "/releases/assets/") {
+		accept = "application/octet-stream"
+	}
+
 	resp, err := req.Request{
 		URL:         url,
+		Auth:        getDownloadAuth(url),
+		Accept:      accept,
 		AutoDiscard: true,
 	}.Get()
 
 	if err != nil {
 		return "", err
+	} else if resp.StatusCode != 200 {
+		return "", fmt.Errorf("Server returned non-ok status code %d", resp.StatusCode)
 	}
 
 	w := bufio.NewWriter(tempFd)
@@ -230,6 +577,27 @@ func downloadArtefactFile(artefact *data.Artefact, version string) (string, erro
 	return tempName, nil
 }
 
+// getDownloadAuth returns auth for downloading file from GitHub API
+func getDownloadAuth(url string) req.Auth {
+	if !strings.HasPrefix(url, "https://api.github.com/repos/") {
+		return nil
+	}
+
+	urlParts := strings.Split(strings.TrimPrefix(url, "https://api.github.com/repos/"), "/")
+
+	if len(urlParts) < 2 {
+		return nil
+	}
+
+	token := github.GetToken(urlParts[0] + "/" + urlParts[1])
+
+	if token == "" {
+		return nil
+	}
+
+	return req.AuthBearer{token}
+}
+
 // unpackArtefactArchive unpacks artefact from archive
 func unpackArtefactArchive(artefact *data.Artefact, file string) (string, error) {
 	spinner.Show("Unpacking data")
@@ -265,61 +633,603 @@ func unpackArtefactArchive(artefact *data.Artefact, file string) (string, error)
 	return "", fmt.Errorf("Can't find binary \"%s\" in unpacked data", artefact.File)
 }
 
-// getArtefactBinaryURL returns URL of binary file
-func getArtefactBinaryURL(artefact *data.Artefact) (string, error) {
-	if httputil.IsURL(artefact.Source) {
-		return artefact.Source, nil
-	}
+// buildArtefact builds artefact from source code of the latest release and
+// returns path to built binary and build log
+func buildArtefact(artefact *data.Artefact) (string, string, error) {
+	spinner.Show("Downloading source code from GitHub")
 
-	assets, err := github.GetLatestReleaseAssets(artefact.Repo)
+	url, err := getSourceTarballURL(artefact)
 
 	if err != nil {
-		return "", err
+		spinner.Done(false)
+		return "", "", err
 	}
 
-	for _, url := range assets {
-		file := path.Base(url)
-		match, _ := path.Match(
-			strings.ToLower(artefact.Source),
-			strings.ToLower(file),
-		)
+	srcFile, err := downloadTempFile(url, artefact.Name+".tar.gz")
 
-		if match {
-			return url, nil
-		}
+	if err != nil {
+		spinner.Done(false)
+		return "", "", err
 	}
 
-	return "", fmt.Errorf("Can't find binary URL")
-}
+	workDir, err := temp.MkDir()
 
-// getArtefactExt returns extension for artefact file
-func getArtefactExt(artefact *data.Artefact) string {
-	switch {
-	case strings.HasSuffix(artefact.Source, ".tar.gz"),
-		strings.HasSuffix(artefact.Source, ".tgz"):
-		return ".tar.gz"
-	case strings.HasSuffix(artefact.Source, ".tar.bz2"),
-		strings.HasSuffix(artefact.Source, ".tbz2"):
-		return ".tar.bz2"
-	case strings.HasSuffix(artefact.Source, ".tar.xz"),
-		strings.HasSuffix(artefact.Source, ".txz"):
-		return ".tar.xz"
-	case strings.HasSuffix(artefact.Source, ".zip"):
-		return ".zip"
+	if err != nil {
+		spinner.Done(false)
+		return "", "", err
 	}
 
-	return ""
-}
+	err = npck.Unpack(srcFile, workDir)
 
-// isArchive returns true if given file is an archive
-func isArchive(artefact *data.Artefact) bool {
-	return getArtefactExt(artefact) != ""
-}
+	if err != nil {
+		spinner.Done(false)
+		return "", "", fmt.Errorf("Can't unpack source code: %v", err)
+	}
 
-// restorePermissions restores permissions for files and directories
-func restorePermissions(dataDir string) {
-	dirs := fsutil.ListAllDirs(dataDir, false)
-	fsutil.ListToAbsolute(dataDir, dirs)
+	spinner.Done(true)
+
+	srcDir := workDir
+	srcItems := fsutil.List(workDir, true)
+
+	// GitHub source archives contain single directory with sources
+	if len(srcItems) == 1 && fsutil.IsDir(path.Join(workDir, srcItems[0])) {
+		srcDir = path.Join(workDir, srcItems[0])
+	}
+
+	homeDir, err := temp.MkDir()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	outputFile := path.Join(homeDir, artefact.Name)
+
+	spinner.Show("Building binary from source code")
+
+	buildLog, err := runBuildCommands(artefact, srcDir, homeDir, outputFile)
+
+	if err == nil && !fsutil.IsExist(outputFile) {
+		err = fmt.Errorf("Build finished, but binary wasn't created")
+	}
+
+	spinner.Done(err == nil)
+
+	if err != nil {
+		return "", buildLog, fmt.Errorf("%v\n%s", err, getBuildLogTail(buildLog, 10))
+	}
+
+	return outputFile, buildLog, nil
+}
+
+// runBuildCommands runs artefact build commands in given directory
+func runBuildCommands(artefact *data.Artefact, srcDir, homeDir, outputFile string) (string, error) {
+	var buildLog bytes.Buffer
+
+	ctx, cancel := context.WithTimeout(context.Background(), artefact.Build.Timeout)
+	defer cancel()
+
+	env := getBuildEnv(artefact, homeDir)
+
+	for _, command := range artefact.Build.Commands {
+		command = strings.ReplaceAll(command, "{output}", outputFile)
+
+		fmt.Fprintf(&buildLog, "$ %s\n", command)
+
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+		cmd.Dir = srcDir
+		cmd.Env = env
+		cmd.Stdout = &buildLog
+		cmd.Stderr = &buildLog
+
+		setProcessGroupKill(cmd)
+
+		err := cmd.Run()
+
+		switch {
+		case ctx.Err() != nil:
+			return buildLog.String(), fmt.Errorf(
+				"Build failed: commands didn't finish in %s",
+				timeutil.Pretty(artefact.Build.Timeout),
+			)
+		case err != nil:
+			return buildLog.String(), fmt.Errorf("Build failed: command %q: %v", command, err)
+		}
+	}
+
+	return buildLog.String(), nil
+}
+
+// setProcessGroupKill configures command to run in its own process group which
+// is killed as a whole on context cancellation. Otherwise child processes keep
+// output pipes open and command doesn't finish after timeout.
+func setProcessGroupKill(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.WaitDelay = 5 * time.Second
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// getBuildEnv returns environment for build commands
+func getBuildEnv(artefact *data.Artefact, homeDir string) []string {
+	env := map[string]string{
+		"PATH":   os.Getenv("PATH"),
+		"HOME":   homeDir,
+		"TMPDIR": homeDir,
+		"LANG":   "C",
+		// Module cache is created inside temporary home directory, so it
+		// must be writable for removing it after build
+		"GOFLAGS":     "-modcacherw",
+		"CGO_ENABLED": "0",
+	}
+
+	for key, value := range artefact.Build.Env {
+		env[key] = value
+	}
+
+	var result []string
+
+	for key, value := range env {
+		result = append(result, key+"="+value)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// getBuildLogTail returns last lines of build log
+func getBuildLogTail(buildLog string, lines int) string {
+	logLines := strings.Split(strings.TrimRight(buildLog, "\n"), "\n")
+
+	if len(logLines) > lines {
+		logLines = logLines[len(logLines)-lines:]
+	}
+
+	return strings.Join(logLines, "\n")
+}
+
+// getSourceTarballURL returns URL of source code archive of the latest release
+func getSourceTarballURL(artefact *data.Artefact) (string, error) {
+	if artefact.Provider == data.PROVIDER_TAGS {
+		tag, err := github.GetLatestTag(artefact.Repo, artefact.Ordering)
+
+		if err != nil {
+			return "", err
+		}
+
+		return tag.TarballURL, nil
+	}
+
+	release, err := github.GetLatestReleaseInfo(artefact.Repo)
+
+	if err != nil {
+		return "", err
+	}
+
+	return "https://api.github.com/repos/" + artefact.Repo + "/tarball/" + release.Version, nil
+}
+
+// checkArtefactArch checks that binary architecture matches declared one
+func checkArtefactArch(artefact *data.Artefact, binFile string) error {
+	if !binary.IsELF(binFile) {
+		return nil
+	}
+
+	info, err := binary.Inspect(binFile)
+
+	if err != nil {
+		return err
+	}
+
+	arch := binary.NormalizeArch(artefact.Arch)
+
+	if info.Arch != arch {
+		return fmt.Errorf(
+			"Binary architecture (%s) doesn't match declared architecture (%s)",
+			info.Arch, arch,
+		)
+	}
+
+	return nil
+}
+
+// testArtefactBinary runs smoke test against staged artefact binary
+func testArtefactBinary(artefact *data.Artefact, binFile string) error {
+	spinner.Show("Running smoke test")
+
+	err := os.Chmod(binFile, 0755)
+
+	if err != nil {
+		spinner.Done(false)
+		return fmt.Errorf("Can't make binary executable: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), artefact.Test.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, binFile, artefact.Test.Args...)
+
+	setProcessGroupKill(cmd)
+
+	output, err := cmd.CombinedOutput()
+
+	switch {
+	case ctx.Err() != nil:
+		spinner.Done(false)
+		return fmt.Errorf("Smoke test failed: binary didn't finish in %g sec", artefact.Test.Timeout.Seconds())
+	case err != nil:
+		spinner.Done(false)
+		return fmt.Errorf("Smoke test failed: %v", err)
+	}
+
+	if artefact.Test.Expect != "" {
+		isMatch, _ := regexp.Match(artefact.Test.Expect, output)
+
+		if !isMatch {
+			spinner.Done(false)
+			return fmt.Errorf(
+				"Smoke test failed: output doesn't match %q (%s)",
+				artefact.Test.Expect, strutil.Ellipsis(strings.TrimSpace(string(output)), 64),
+			)
+		}
+	}
+
+	spinner.Done(true)
+
+	return nil
+}
+
+// getArtefactBinaryURL returns URL of binary file
+func getArtefactBinaryURL(artefact *data.Artefact) (string, error) {
+	if httputil.IsURL(artefact.Source) {
+		return artefact.Source, nil
+	}
+
+	switch artefact.Provider {
+	case data.PROVIDER_TAGS:
+		return getTagArchiveURL(artefact)
+	case data.PROVIDER_ACTIONS:
+		return getWorkflowArtifactURL(artefact)
+	}
+
+	release, err := github.GetLatestReleaseInfo(artefact.Repo)
+
+	if err != nil {
+		return "", err
+	}
+
+	asset := findMatchingAsset(release.Assets, artefact.Source)
+
+	if asset == nil {
+		return "", getAssetMatchError(artefact, release)
+	}
+
+	return asset.URL, nil
+}
+
+// getTagArchiveURL returns URL of auto-generated source archive of the latest tag
+func getTagArchiveURL(artefact *data.Artefact) (string, error) {
+	tag, err := github.GetLatestTag(artefact.Repo, artefact.Ordering)
+
+	if err != nil {
+		return "", err
+	}
+
+	switch artefact.Source {
+	case data.SOURCE_TARBALL:
+		return tag.TarballURL, nil
+	case data.SOURCE_ZIPBALL:
+		return tag.ZipballURL, nil
+	}
+
+	return "", fmt.Errorf("Unsupported source %q for tags provider", artefact.Source)
+}
+
+// getWorkflowArtifactURL returns URL of artifact from the latest successful
+// workflow run
+func getWorkflowArtifactURL(artefact *data.Artefact) (string, error) {
+	run, err := getLatestWorkflowRun(artefact)
+
+	if err != nil {
+		return "", err
+	}
+
+	artifacts, err := github.GetRunArtifacts(artefact.Repo, run.ID)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.Name != artefact.Actions.Artifact {
+			continue
+		}
+
+		if artifact.Expired {
+			return "", fmt.Errorf(
+				"Artifact %q of run #%d is expired", artifact.Name, run.Number,
+			)
+		}
+
+		return artifact.URL, nil
+	}
+
+	return "", fmt.Errorf(
+		"Run #%d of workflow %s has no artifact %q",
+		run.Number, artefact.Actions.Workflow, artefact.Actions.Artifact,
+	)
+}
+
+// getLatestWorkflowRun returns the latest successful run of artefact workflow
+func getLatestWorkflowRun(artefact *data.Artefact) (*github.WorkflowRun, error) {
+	if github.GetToken(artefact.Repo) == "" {
+		return nil, fmt.Errorf("GitHub token is required for actions provider")
+	}
+
+	return github.GetLatestWorkflowRun(
+		artefact.Repo, artefact.Actions.Workflow, artefact.Actions.Branch,
+	)
+}
+
+// getWorkflowRunVersion returns artefact version for given workflow run
+func getWorkflowRunVersion(artefact *data.Artefact, run *github.WorkflowRun) string {
+	if artefact.Actions.Version == data.ACTIONS_VERSION_SHA {
+		return run.HeadSHA[:min(len(run.HeadSHA), 12)]
+	}
+
+	return strconv.Itoa(run.Number)
+}
+
+// findMatchingAsset returns the first asset which name matches given pattern
+func findMatchingAsset(assets []*github.Asset, pattern string) *github.Asset {
+	for _, asset := range assets {
+		match, _ := path.Match(
+			strings.ToLower(pattern),
+			strings.ToLower(path.Base(asset.URL)),
+		)
+
+		if match {
+			return asset
+		}
+	}
+
+	return nil
+}
+
+// getAssetMatchError returns error with detailed info about release assets
+// which doesn't match artefact source pattern
+func getAssetMatchError(artefact *data.Artefact, release *github.Release) error {
+	version := strings.TrimLeft(release.Version, "v")
+
+	if len(release.Assets) == 0 {
+		return fmt.Errorf("Can't find binary URL: release %s has no assets", version)
+	}
+
+	var names []string
+
+	for _, asset := range release.Assets {
+		names = append(names, path.Base(asset.URL))
+	}
+
+	msg := fmt.Sprintf(
+		"Can't find binary URL: no assets of release %s match %q\n", version, artefact.Source,
+	)
+
+	msg += fmt.Sprintf("   Release assets: %s\n", strings.Join(names, ", "))
+	msg += fmt.Sprintf("   Closest matches: %s", strings.Join(findClosestAssets(names, artefact.Source, 3), ", "))
+
+	// Pattern can contain version of the latest release, so we have to make
+	// it generic for checking previous releases
+	pattern := strings.ReplaceAll(artefact.Source, version, "*")
+	releases, err := github.GetReleases(artefact.Repo, 10)
+
+	if err != nil {
+		return errors.New(msg)
+	}
+
+	var checked int
+
+	for _, r := range releases {
+		if r.Version == release.Version {
+			continue
+		}
+
+		checked++
+		asset := findMatchingAsset(r.Assets, pattern)
+
+		if asset != nil {
+			msg += fmt.Sprintf(
+				"\n   Pattern matched asset %s in release %s",
+				path.Base(asset.URL), strings.TrimLeft(r.Version, "v"),
+			)
+
+			return errors.New(msg)
+		}
+	}
+
+	if checked == 0 {
+		return errors.New(msg)
+	}
+
+	msg += fmt.Sprintf("\n   Pattern doesn't match assets of %d previous releases", checked)
+
+	return errors.New(msg)
+}
+
+// findClosestAssets returns names of assets which are most similar to given
+// pattern
+func findClosestAssets(names []string, pattern string, count int) []string {
+	pattern = strings.ToLower(pattern)
+	scores := map[string]int{}
+	patternTokens := getAssetNameTokens(pattern)
+
+	for _, name := range names {
+		lowerName := strings.ToLower(name)
+		score := getEditDistance(strings.ReplaceAll(pattern, "*", ""), lowerName)
+
+		for _, token := range getAssetNameTokens(lowerName) {
+			if slices.Contains(patternTokens, token) {
+				score -= 5
+			}
+		}
+
+		scores[name] = score
+	}
+
+	result := slices.Clone(names)
+
+	slices.SortStableFunc(result, func(a, b string) int {
+		return scores[a] - scores[b]
+	})
+
+	return result[:min(count, len(result))]
+}
+
+// getAssetNameTokens returns arch and OS tokens from asset name
+func getAssetNameTokens(name string) []string {
+	var result []string
+
+	for _, token := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	}) {
+		switch token {
+		case "linux", "darwin", "macos", "windows", "freebsd", "musl", "gnu":
+			result = append(result, token)
+		default:
+			arch := binary.NormalizeArch(token)
+
+			if arch != token || slices.Contains([]string{"x86_64", "aarch64", "i386", "arm"}, arch) {
+				result = append(result, arch)
+			}
+		}
+	}
+
+	return result
+}
+
+// getEditDistance returns Levenshtein distance between two strings
+func getEditDistance(s1, s2 string) int {
+	r1, r2 := []rune(s1), []rune(s2)
+	row := make([]int, len(r2)+1)
+
+	for i := range row {
+		row[i] = i
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		prev := row[0]
+		row[0] = i
+
+		for j := 1; j <= len(r2); j++ {
+			cur := row[j]
+
+			if r1[i-1] == r2[j-1] {
+				row[j] = prev
+			} else {
+				row[j] = min(prev, row[j], row[j-1]) + 1
+			}
+
+			prev = cur
+		}
+	}
+
+	return row[len(r2)]
+}
+
+// getAssetName returns name of upstream asset with artefact data
+func getAssetName(artefact *data.Artefact, sourceURL string) string {
+	switch {
+	case sourceURL == "":
+		return ""
+	case artefact.Provider == data.PROVIDER_ACTIONS:
+		return artefact.Actions.Artifact + ".zip"
+	case artefact.IsSourceArchive():
+		return artefact.Source
+	}
+
+	return path.Base(sourceURL)
+}
+
+// getArtefactExt returns extension for artefact file
+func getArtefactExt(artefact *data.Artefact) string {
+	switch {
+	case artefact.Provider == data.PROVIDER_ACTIONS:
+		return ".zip"
+	case artefact.Source == data.SOURCE_TARBALL:
+		return ".tar.gz"
+	case artefact.Source == data.SOURCE_ZIPBALL:
+		return ".zip"
+	case strings.HasSuffix(artefact.Source, ".tar.gz"),
+		strings.HasSuffix(artefact.Source, ".tgz"):
+		return ".tar.gz"
+	case strings.HasSuffix(artefact.Source, ".tar.bz2"),
+		strings.HasSuffix(artefact.Source, ".tbz2"):
+		return ".tar.bz2"
+	case strings.HasSuffix(artefact.Source, ".tar.xz"),
+		strings.HasSuffix(artefact.Source, ".txz"):
+		return ".tar.xz"
+	case strings.HasSuffix(artefact.Source, ".zip"):
+		return ".zip"
+	}
+
+	return ""
+}
+
+// isArchive returns true if given file is an archive
+func isArchive(artefact *data.Artefact) bool {
+	return getArtefactExt(artefact) != ""
+}
+
+// getLatestVersion returns the latest version of artefact and its publish date
+func getLatestVersion(artefact *data.Artefact) (string, time.Time, error) {
+	switch artefact.Provider {
+	case data.PROVIDER_TAGS:
+		tag, err := github.GetLatestTag(artefact.Repo, artefact.Ordering)
+
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		return tag.Version(), time.Time{}, nil
+
+	case data.PROVIDER_ACTIONS:
+		run, err := getLatestWorkflowRun(artefact)
+
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		return getWorkflowRunVersion(artefact, run), run.UpdatedAt, nil
+	}
+
+	return github.GetLatestReleaseVersion(artefact.Repo)
+}
+
+// setRepoTokens configures per-repository GitHub tokens defined for artefacts
+func setRepoTokens(artefacts data.Artefacts) {
+	for _, artefact := range artefacts {
+		if artefact.Token != "" {
+			github.SetRepoToken(artefact.Repo, artefact.Token)
+		}
+	}
+}
+
+// getDirSize returns size of all files in given directory
+func getDirSize(dir string) int64 {
+	var result int64
+
+	for _, file := range fsutil.List(dir, true, fsutil.ListingFilter{Perms: "FR"}) {
+		result += fsutil.GetSize(path.Join(dir, file))
+	}
+
+	return result
+}
+
+// restorePermissions restores permissions for files and directories
+func restorePermissions(dataDir string) {
+	dirs := fsutil.ListAllDirs(dataDir, false)
+	fsutil.ListToAbsolute(dataDir, dirs)
 
 	for _, dir := range dirs {
 		os.Chmod(dir, 0755)
@@ -333,6 +1243,155 @@ func restorePermissions(dataDir string) {
 	}
 }
 
+// updateLatestLink points link to the latest version of artefact to given version
+func updateLatestLink(dataDir, name, version string) error {
+	latestLink := path.Join(dataDir, name, "latest")
+
+	if fsutil.IsLink(latestLink) {
+		os.Remove(latestLink)
+	}
+
+	if !fsutil.IsExist(latestLink) {
+		err := os.Symlink(version, latestLink)
+
+		if err != nil {
+			return fmt.Errorf("Can't create link to the latest release: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// setArtefactChannel points release channel of artefact to given version
+func setArtefactChannel(dataDir, name, channel, version string) error {
+	artefactDir := path.Join(dataDir, name)
+	state, err := data.ReadState(artefactDir)
+
+	if err != nil {
+		return err
+	}
+
+	state.SetChannel(channel, version)
+
+	err = state.Write(artefactDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't save artefact state: %v", err)
+	}
+
+	return nil
+}
+
+// setArtefactOrdering saves versions ordering of artefact to its state and
+// updates index if ordering was changed
+func setArtefactOrdering(dataDir string, artefact *data.Artefact) error {
+	artefactDir := path.Join(dataDir, artefact.StorageDir())
+	state, err := data.ReadState(artefactDir)
+
+	if err != nil {
+		return err
+	}
+
+	if state.Ordering == artefact.Ordering {
+		return nil
+	}
+
+	state.Ordering = artefact.Ordering
+	err = state.Write(artefactDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't save artefact state: %v", err)
+	}
+
+	// There is no new version which triggers index update, so versions in
+	// index must be re-sorted right away
+	return updateIndexState(dataDir, artefact.StorageDir())
+}
+
+// autoPromoteArtefact promotes beta version to stable channel if there were no
+// newer releases for configured number of days and returns promoted version
+func autoPromoteArtefact(artefact *data.Artefact, dataDir string) (string, error) {
+	state, err := data.ReadState(path.Join(dataDir, artefact.StorageDir()))
+
+	if err != nil {
+		return "", err
+	}
+
+	beta := state.Channels[data.CHANNEL_BETA]
+	period := time.Duration(options.GetI(OPT_PROMOTE_AFTER)) * 24 * time.Hour
+
+	if beta == nil || beta.Version == state.GetChannel(data.CHANNEL_STABLE) ||
+		time.Since(beta.Updated) < period {
+		return "", nil
+	}
+
+	err = setArtefactChannel(dataDir, artefact.StorageDir(), data.CHANNEL_STABLE, beta.Version)
+
+	if err != nil {
+		return "", err
+	}
+
+	fmtc.Printfn(
+		"   {g}Version {*}%s{!g} promoted to {*}%s{!g} channel{!}",
+		beta.Version, data.CHANNEL_STABLE,
+	)
+
+	return beta.Version, nil
+}
+
+// updateIndex adds info about stored versions to index and removes info about
+// deleted versions
+func updateIndex(dataDir string, added, removed map[string][]string) error {
+	index, err := readLocalIndex(dataDir)
+
+	// Index must be rebuilt if it doesn't exist or was created by older version
+	// of artefactor
+	if err != nil || index.Schema != data.INDEX_SCHEMA {
+		return rebuildIndex(dataDir)
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	for name, versions := range removed {
+		for _, version := range versions {
+			index.RemoveVersion(name, version)
+		}
+	}
+
+	for name, versions := range added {
+		for _, version := range versions {
+			err = index.AddVersion(dataDir, name, version)
+
+			if err != nil {
+				return fmt.Errorf("Can't add %s:%s to index: %v", name, version, err)
+			}
+		}
+	}
+
+	printIndexWarnings(index)
+
+	return writeIndex(index, dataDir)
+}
+
+// updateFeed updates feed and changes list with versions added to index
+func updateFeed(dataDir string, oldIndex *data.Index) error {
+	newIndex, err := readLocalIndex(dataDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't read index: %v", err)
+	}
+
+	err = feed.Update(dataDir, oldIndex, newIndex, options.GetS(OPT_URL), options.GetI(OPT_FEED_SIZE))
+
+	if err != nil {
+		return fmt.Errorf("Can't update feed: %v", err)
+	}
+
+	return nil
+}
+
 // rebuildIndex rebuilds index
 func rebuildIndex(dataDir string) error {
 	index, err := data.BuildIndex(dataDir)
@@ -341,11 +1400,53 @@ func rebuildIndex(dataDir string) error {
 		return fmt.Errorf("Can't build index: %v", err)
 	}
 
-	err = index.Write(path.Join(dataDir, "index.json"))
+	printIndexWarnings(index)
+
+	return writeIndex(index, dataDir)
+}
+
+// printIndexWarnings prints non-fatal problems found while building index
+func printIndexWarnings(index *data.Index) {
+	for _, warn := range index.Warnings() {
+		terminal.Warn("Warning: %v", warn)
+	}
+}
+
+// writeIndex writes index to data directory and signs it if signing key
+// is provided
+func writeIndex(index *data.Index, dataDir string) error {
+	var err error
+	var key ed25519.PrivateKey
+
+	// Key is read before writing index, so index will not be updated without
+	// signature if key is invalid
+	if options.Has(OPT_SIGN_KEY) {
+		key, err = data.ReadPrivateKey(options.GetS(OPT_SIGN_KEY))
+
+		if err != nil {
+			return fmt.Errorf("Can't sign index: %v", err)
+		}
+	}
+
+	indexFile := path.Join(dataDir, "index.json")
+
+	if key == nil && fsutil.IsExist(indexFile+data.SIGNATURE_EXT) {
+		terminal.Warn("Signing key is not set, so stale index signature will be removed")
+	}
+
+	err = index.Write(indexFile, key)
 
 	if err != nil {
 		return fmt.Errorf("Can't save index: %v", err)
 	}
 
+	if options.GetB(OPT_HTML) && !index.IsEmpty() {
+		err = catalog.Render(index, dataDir, options.GetS(OPT_URL))
+
+		if err != nil {
+			return fmt.Errorf("Can't render catalog: %v", err)
+		}
+	}
+
 	return nil
 }
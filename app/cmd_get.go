@@ -8,6 +8,7 @@ package app
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
@@ -55,10 +56,17 @@ func cmdGet(args options.Arguments) error {
 	version := args.Get(2).String()
 	var versionInfo *data.ArtefactVersion
 
-	if version == "" {
-		versionInfo = artefactInfo.Latest()
-	} else {
+	switch {
+	case version != "":
 		versionInfo = artefactInfo.Find(version)
+	case options.Has(OPT_CHANNEL):
+		versionInfo = artefactInfo.Channel(options.GetS(OPT_CHANNEL))
+
+		if versionInfo == nil {
+			return fmt.Errorf("There is no channel %q for %s", options.GetS(OPT_CHANNEL), name)
+		}
+	default:
+		versionInfo = artefactInfo.Latest()
 	}
 
 	if versionInfo == nil {
@@ -79,21 +87,22 @@ func getArtefact(storage, name string, info *data.ArtefactVersion) error {
 	fmtc.Printfn("{*}Downloading files of {?primary}%s{!*}:%s{!}{*} artefact…{!}", name, info.Version)
 
 	for _, file := range info.Files {
-		fileName := stripArchFromBinaryName(file)
-		fileURL := storage + "/" + path.Join(name, info.Version, file)
+		fileName := stripArchFromBinaryName(file.Name)
+		fileURL := storage + "/" + path.Join(name, info.Version, file.Name)
 
-		err := fetchArtefactBinary(fileName, fileURL)
+		err := fetchArtefactBinary(fileName, fileURL, file.SHA256)
 
 		if err != nil {
-			terminal.Error("Error while downloading artefact binary: %v")
+			terminal.Error("Error while downloading artefact binary: %v", err)
 			fmtc.NewLine()
+			continue
 		}
 
 		if options.GetB(OPT_INSTALL) {
 			err = installArtefactBinary(fileName)
 
 			if err != nil {
-				terminal.Error("Error while installing artefact binary: %v")
+				terminal.Error("Error while installing artefact binary: %v", err)
 				fmtc.NewLine()
 			}
 		}
@@ -102,8 +111,9 @@ func getArtefact(storage, name string, info *data.ArtefactVersion) error {
 	return nil
 }
 
-// fetchArtefactBinary fetches artefact binary from remote storage
-func fetchArtefactBinary(fileName, url string) error {
+// fetchArtefactBinary fetches artefact binary from remote storage and verifies
+// its checksum if it's known
+func fetchArtefactBinary(fileName, url, checksum string) error {
 	pb := progress.New(0, fileName)
 
 	pbs := progress.DefaultSettings
@@ -129,7 +139,7 @@ func fetchArtefactBinary(fileName, url string) error {
 
 	defer resp.Body.Close()
 
-	fd, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY, 0644)
+	fd, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 
 	if err != nil {
 		return fmt.Errorf("Can't create file %q: %v", fileName, err)
@@ -137,15 +147,22 @@ func fetchArtefactBinary(fileName, url string) error {
 
 	defer fd.Close()
 
+	hasher := sha256.New()
+
 	pb.SetTotal(resp.ContentLength)
 	pb.Start()
-	_, err = io.Copy(fd, pb.Reader(resp.Body))
+	_, err = io.Copy(io.MultiWriter(fd, hasher), pb.Reader(resp.Body))
 	pb.Finish()
 
 	if err != nil {
 		return fmt.Errorf("Can't save binary: %v", err)
 	}
 
+	if checksum != "" && fmt.Sprintf("%x", hasher.Sum(nil)) != checksum {
+		os.Remove(fileName)
+		return fmt.Errorf("Checksum of %s doesn't match checksum from index", fileName)
+	}
+
 	return nil
 }
 
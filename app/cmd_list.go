@@ -8,8 +8,13 @@ package app
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/fmtutil"
@@ -19,35 +24,100 @@ import (
 	"github.com/essentialkaos/ek/v13/pager"
 	"github.com/essentialkaos/ek/v13/path"
 	"github.com/essentialkaos/ek/v13/req"
+	"github.com/essentialkaos/ek/v13/strutil"
 	"github.com/essentialkaos/ek/v13/terminal"
 	"github.com/essentialkaos/ek/v13/terminal/tty"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/yaml/v2"
 
 	"github.com/essentialkaos/artefactor/data"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+const (
+	FORMAT_JSON = "json"
+	FORMAT_YAML = "yaml"
+	FORMAT_TSV  = "tsv"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// listArtefact contains artefact info and versions selected for listing
+type listArtefact struct {
+	Info     *data.ArtefactInfo
+	Versions []*data.ArtefactVersion
+}
+
+// listRecord contains info about artefact version in machine-readable output.
+// Records are sorted by artefact name and version (oldest first). Dates are in
+// RFC 3339 format and empty if unknown. TSV output contains header and columns
+// name, version, latest, size, published, downloaded, channels (comma-separated),
+// pinned, repo and tag.
+type listRecord struct {
+	Name       string      `json:"name" yaml:"name"`
+	Version    string      `json:"version" yaml:"version"`
+	Latest     bool        `json:"latest" yaml:"latest"`
+	Size       int64       `json:"size" yaml:"size"`
+	Published  string      `json:"published" yaml:"published"`
+	Downloaded string      `json:"downloaded" yaml:"downloaded"`
+	Channels   []string    `json:"channels" yaml:"channels"`
+	Pinned     bool        `json:"pinned" yaml:"pinned"`
+	PinReason  string      `json:"pin_reason" yaml:"pin_reason"`
+	Repo       string      `json:"repo" yaml:"repo"`
+	Tag        string      `json:"tag" yaml:"tag"`
+	URL        string      `json:"url" yaml:"url"`
+	Files      []*listFile `json:"files" yaml:"files"`
+}
+
+// listFile contains info about version file in machine-readable output
+type listFile struct {
+	Name     string `json:"name" yaml:"name"`
+	Size     int64  `json:"size" yaml:"size"`
+	SHA256   string `json:"sha256" yaml:"sha256"`
+	Platform string `json:"platform" yaml:"platform"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // cmdList is "list" command handler
 func cmdList(args options.Arguments) error {
 	if !args.Has(0) {
 		return fmt.Errorf("You must provide path to data directory or URL of storage")
 	}
 
-	var err error
-	var index *data.Index
+	format := options.GetS(OPT_FORMAT)
 
-	switch {
-	case fsutil.IsExist(args.Get(0).Clean().String()):
-		index, err = readLocalIndex(args.Get(0).Clean().String())
-	case strings.Contains(args.Get(0).String(), "."):
-		index, err = readRemoteIndex(args.Get(0).String())
-	default:
-		return fmt.Errorf("Invalid data directory or URL")
+	if !slices.Contains([]string{"", FORMAT_JSON, FORMAT_YAML, FORMAT_TSV}, format) {
+		return fmt.Errorf("Unsupported output format %q", format)
 	}
 
+	since, err := parseSince(options.GetS(OPT_SINCE))
+
+	if err != nil {
+		return err
+	}
+
+	index, err := readIndex(args.Get(0))
+
 	if err != nil {
 		return fmt.Errorf("Can't get index data: %v", err)
-	} else if index.IsEmpty() {
+	}
+
+	artefacts, err := filterArtefacts(
+		index, args.Get(1).String(), since, options.GetB(OPT_LATEST_ONLY),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if format != "" {
+		return printListRecords(getListRecords(artefacts), format)
+	}
+
+	if len(artefacts) == 0 {
 		terminal.Warn("No artefacts found")
 		return nil
 	}
@@ -58,26 +128,47 @@ func cmdList(args options.Arguments) error {
 		}
 	}
 
-	for _, info := range index.Artefacts {
-		size := fmtutil.PrettyNum(len(info.Versions))
+	for _, a := range artefacts {
+		info := a.Info
+		size := fmtutil.PrettyNum(len(a.Versions))
 
 		fmtc.Printfn("{s-}┌{!}{*@} %s {!}{#240}{*@} %s {!}", info.Name, size)
 		fmtc.Printfn("{s-}│{!}")
 
-		for i, version := range info.Versions {
-			if i+1 != len(info.Versions) {
-				fmtc.Printfn(
-					"{s-}├{!} {s}%s{!} {s-}(%s){!}",
-					version.Version,
-					fmtutil.PrettySize(version.Size),
-				)
+		for i, version := range a.Versions {
+			if i+1 != len(a.Versions) {
+				fmtc.Printf("{s-}├{!} {s}%s{!}", version.Version)
+			} else {
+				fmtc.Printf("{s-}└{!} {*}%s{!}", version.Version)
+			}
+
+			if getVersionDate(version).IsZero() {
+				fmtc.Printf(" {s-}(%s){!}", fmtutil.PrettySize(version.Size))
 			} else {
-				fmtc.Printfn(
-					"{s-}└{!} {*}%s{!} {s-}(%s){!}",
-					version.Version,
+				fmtc.Printf(
+					" {s-}(%s, %s){!}",
 					fmtutil.PrettySize(version.Size),
+					timeutil.Format(getVersionDate(version), "%Y/%m/%d"),
 				)
 			}
+
+			if version.Linkage() != "" {
+				fmtc.Printf(" {s-}[%s]{!}", version.Linkage())
+			}
+
+			for _, channel := range getVersionChannels(info, version.Version) {
+				fmtc.Printf(" {c}%s{!}", channel)
+			}
+
+			if info.Pin != nil && info.Pin.Version == version.Version {
+				if info.Pin.Reason != "" {
+					fmtc.Printf(" {y}[pinned: %s]{!}", info.Pin.Reason)
+				} else {
+					fmtc.Printf(" {y}[pinned]{!}")
+				}
+			}
+
+			fmtc.NewLine()
 		}
 
 		fmtc.NewLine()
@@ -86,8 +177,198 @@ func cmdList(args options.Arguments) error {
 	return nil
 }
 
+// getVersionChannels returns sorted list of channels which point to given version
+func getVersionChannels(info *data.ArtefactInfo, version string) []string {
+	var result []string
+
+	for channel, channelVersion := range info.Channels {
+		if channelVersion == version {
+			result = append(result, channel)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// getVersionDate returns publish date of version or date of download if
+// publish date is unknown
+func getVersionDate(version *data.ArtefactVersion) time.Time {
+	if !version.Published.IsZero() {
+		return version.Published
+	}
+
+	return version.Downloaded
+}
+
+// parseSince parses date (2006-01-02) or period (30d, 2w) into time
+func parseSince(since string) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, since, time.Local)
+
+	if err == nil {
+		return date, nil
+	}
+
+	period, err := timeutil.ParseDuration(since, 'd')
+
+	if err != nil || period <= 0 {
+		return time.Time{}, fmt.Errorf("Invalid date or period %q", since)
+	}
+
+	return time.Now().Add(-period), nil
+}
+
+// filterArtefacts returns artefacts with name matching given glob pattern and
+// versions published after given date
+func filterArtefacts(index *data.Index, pattern string, since time.Time, latestOnly bool) ([]*listArtefact, error) {
+	var result []*listArtefact
+
+	if index.IsEmpty() {
+		return nil, nil
+	}
+
+	for _, info := range index.Artefacts {
+		if pattern != "" {
+			isMatch, err := path.Match(pattern, info.Name)
+
+			if err != nil {
+				return nil, fmt.Errorf("Invalid name pattern %q: %v", pattern, err)
+			} else if !isMatch {
+				continue
+			}
+		}
+
+		versions := info.Versions
+
+		if latestOnly && info.Latest() != nil {
+			versions = []*data.ArtefactVersion{info.Latest()}
+		}
+
+		if !since.IsZero() {
+			versions = slices.DeleteFunc(slices.Clone(versions), func(v *data.ArtefactVersion) bool {
+				return getVersionDate(v).Before(since)
+			})
+		}
+
+		if len(versions) != 0 {
+			result = append(result, &listArtefact{info, versions})
+		}
+	}
+
+	return result, nil
+}
+
+// getListRecords converts artefacts info into records for machine-readable output
+func getListRecords(artefacts []*listArtefact) []*listRecord {
+	result := []*listRecord{}
+
+	for _, a := range artefacts {
+		latest := a.Info.Latest()
+
+		for _, version := range a.Versions {
+			record := &listRecord{
+				Name:       a.Info.Name,
+				Version:    version.Version,
+				Latest:     latest != nil && latest.Version == version.Version,
+				Size:       version.Size,
+				Published:  formatListDate(version.Published),
+				Downloaded: formatListDate(version.Downloaded),
+				Channels:   getVersionChannels(a.Info, version.Version),
+				Repo:       version.Repo,
+				Tag:        version.Tag,
+				URL:        version.URL,
+				Files:      []*listFile{},
+			}
+
+			if record.Channels == nil {
+				record.Channels = []string{}
+			}
+
+			if a.Info.Pin != nil && a.Info.Pin.Version == version.Version {
+				record.Pinned, record.PinReason = true, a.Info.Pin.Reason
+			}
+
+			for _, file := range version.Files {
+				record.Files = append(record.Files, &listFile{
+					Name:     file.Name,
+					Size:     file.Size,
+					SHA256:   file.SHA256,
+					Platform: file.Platform,
+				})
+			}
+
+			result = append(result, record)
+		}
+	}
+
+	return result
+}
+
+// printListRecords prints records in given format
+func printListRecords(records []*listRecord, format string) error {
+	switch format {
+	case FORMAT_JSON:
+		recordsData, err := json.MarshalIndent(records, "", "  ")
+
+		if err != nil {
+			return fmt.Errorf("Can't encode data: %v", err)
+		}
+
+		fmt.Println(string(recordsData))
+
+	case FORMAT_YAML:
+		recordsData, err := yaml.Marshal(records)
+
+		if err != nil {
+			return fmt.Errorf("Can't encode data: %v", err)
+		}
+
+		fmt.Print(string(recordsData))
+
+	case FORMAT_TSV:
+		fmt.Println("name\tversion\tlatest\tsize\tpublished\tdownloaded\tchannels\tpinned\trepo\ttag")
+
+		for _, r := range records {
+			fmt.Println(strings.Join([]string{
+				r.Name, r.Version, strconv.FormatBool(r.Latest),
+				strconv.FormatInt(r.Size, 10), r.Published, r.Downloaded,
+				strings.Join(r.Channels, ","), strconv.FormatBool(r.Pinned),
+				r.Repo, r.Tag,
+			}, "\t"))
+		}
+	}
+
+	return nil
+}
+
+// formatListDate formats date for machine-readable output
+func formatListDate(d time.Time) string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.UTC().Format(time.RFC3339)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// readIndex reads index from data directory or remote storage
+func readIndex(source options.Argument) (*data.Index, error) {
+	switch {
+	case fsutil.IsExist(source.Clean().String()):
+		return readLocalIndex(source.Clean().String())
+	case strings.Contains(source.String(), "."):
+		return readRemoteIndex(source.String())
+	}
+
+	return nil, fmt.Errorf("Invalid data directory or URL")
+}
+
 // readLocalIndex reads index from filesystem
 func readLocalIndex(dir string) (*data.Index, error) {
 	indexFile := path.Join(dir, "index.json")
@@ -104,6 +385,12 @@ func readLocalIndex(dir string) (*data.Index, error) {
 		return nil, err
 	}
 
+	err = index.CheckSchema()
+
+	if err != nil {
+		return nil, err
+	}
+
 	return index, nil
 }
 
@@ -113,25 +400,75 @@ func readRemoteIndex(url string) (*data.Index, error) {
 		url = "https://" + url
 	}
 
-	resp, err := req.Request{
-		URL:         url + "/index.json",
-		Accept:      req.CONTENT_TYPE_JSON,
-		ContentType: req.CONTENT_TYPE_JSON,
-		AutoDiscard: true,
-	}.Get()
+	indexData, err := fetchStorageFile(url + "/index.json")
 
 	if err != nil {
-		return nil, fmt.Errorf("Can't send request: %v", err)
-	} else if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Storage returned non-ok status code %d", resp.StatusCode)
+		return nil, err
+	}
+
+	err = verifyIndexSignature(url, indexData)
+
+	if err != nil {
+		return nil, err
 	}
 
 	index := &data.Index{}
-	err = resp.JSON(index)
+	err = json.Unmarshal(indexData, index)
 
 	if err != nil {
 		return nil, fmt.Errorf("Can't decode index: %v", err)
 	}
 
+	err = index.CheckSchema()
+
+	if err != nil {
+		return nil, err
+	}
+
 	return index, nil
 }
+
+// verifyIndexSignature verifies index signature if public key is provided
+func verifyIndexSignature(url string, indexData []byte) error {
+	publicKey := strutil.Q(options.GetS(OPT_PUBLIC_KEY), os.Getenv("ARTEFACTOR_PUBLIC_KEY"))
+
+	if publicKey == "" {
+		return nil
+	}
+
+	key, err := data.ParsePublicKey(publicKey)
+
+	if err != nil {
+		return err
+	}
+
+	signature, err := fetchStorageFile(url + "/index.json" + data.SIGNATURE_EXT)
+
+	if err != nil {
+		return fmt.Errorf("Can't fetch index signature: %v", err)
+	}
+
+	err = data.VerifySignature(indexData, signature, key)
+
+	if err != nil {
+		return fmt.Errorf("Index signature verification failed: %v", err)
+	}
+
+	return nil
+}
+
+// fetchStorageFile fetches file from remote storage
+func fetchStorageFile(url string) ([]byte, error) {
+	resp, err := req.Request{
+		URL:         url,
+		AutoDiscard: true,
+	}.Get()
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't send request: %v", err)
+	} else if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Storage returned non-ok status code %d", resp.StatusCode)
+	}
+
+	return resp.Bytes(), nil
+}
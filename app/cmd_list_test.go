@@ -0,0 +1,160 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/yaml/v2"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestParseSince(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		since   string
+		result  time.Time
+		isError bool
+	}{
+		{"", time.Time{}, false},
+		{"2024-10-09", time.Date(2024, 10, 9, 0, 0, 0, 0, time.Local), false},
+		{"30d", now.AddDate(0, 0, -30), false},
+		{"30", now.AddDate(0, 0, -30), false},
+		{"2w", now.AddDate(0, 0, -14), false},
+		{"2024/10/09", time.Time{}, true},
+		{"abc", time.Time{}, true},
+		{"0d", time.Time{}, true},
+	}
+
+	for _, test := range tests {
+		result, err := parseSince(test.since)
+
+		switch {
+		case test.isError && err == nil:
+			t.Errorf("parseSince(%q) must return error", test.since)
+		case !test.isError && err != nil:
+			t.Errorf("parseSince(%q) returned error: %v", test.since, err)
+		case result.Sub(test.result).Abs() > time.Minute:
+			t.Errorf("parseSince(%q) = %v, want %v", test.since, result, test.result)
+		}
+	}
+}
+
+func TestFilterArtefacts(t *testing.T) {
+	d := time.Date(2024, 10, 9, 0, 0, 0, 0, time.UTC)
+
+	index := &data.Index{
+		Artefacts: []*data.ArtefactInfo{
+			{
+				Name: "fzf",
+				Versions: []*data.ArtefactVersion{
+					{Version: "0.50.0", Published: d.AddDate(0, -2, 0)},
+					{Version: "0.55.0", Published: d},
+				},
+			},
+			{
+				Name: "shellcheck",
+				Versions: []*data.ArtefactVersion{
+					{Version: "0.9.0", Published: d.AddDate(-1, 0, 0)},
+					{Version: "0.10.0", Published: d.AddDate(0, -1, 0)},
+				},
+				Pin: &data.Pin{Version: "0.9.0"},
+			},
+			{
+				Name: "yq",
+				Versions: []*data.ArtefactVersion{
+					{Version: "4.44.0", Downloaded: d.AddDate(0, 0, -1)},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		pattern    string
+		since      time.Time
+		latestOnly bool
+		result     []string
+	}{
+		{"", time.Time{}, false, []string{"fzf:0.50.0", "fzf:0.55.0", "shellcheck:0.9.0", "shellcheck:0.10.0", "yq:4.44.0"}},
+		{"s*", time.Time{}, false, []string{"shellcheck:0.9.0", "shellcheck:0.10.0"}},
+		{"", time.Time{}, true, []string{"fzf:0.55.0", "shellcheck:0.9.0", "yq:4.44.0"}},
+		{"", d.AddDate(0, -1, -1), false, []string{"fzf:0.55.0", "shellcheck:0.10.0", "yq:4.44.0"}},
+		{"", d.AddDate(0, -1, -1), true, []string{"fzf:0.55.0", "yq:4.44.0"}},
+		{"unknown", time.Time{}, false, nil},
+	}
+
+	for _, test := range tests {
+		artefacts, err := filterArtefacts(index, test.pattern, test.since, test.latestOnly)
+
+		if err != nil {
+			t.Errorf("filterArtefacts(%q) returned error: %v", test.pattern, err)
+			continue
+		}
+
+		var result []string
+
+		for _, a := range artefacts {
+			for _, v := range a.Versions {
+				result = append(result, a.Info.Name+":"+v.Version)
+			}
+		}
+
+		if !slices.Equal(result, test.result) {
+			t.Errorf(
+				"filterArtefacts(%q, %v, %t) = %v, want %v",
+				test.pattern, test.since, test.latestOnly, result, test.result,
+			)
+		}
+	}
+
+	_, err := filterArtefacts(index, "[", time.Time{}, false)
+
+	if err == nil {
+		t.Errorf("filterArtefacts() with malformed pattern must return error")
+	}
+}
+
+func TestListRecordsYAML(t *testing.T) {
+	records := []*listRecord{{
+		Name:      "test: #1",
+		Version:   "1.0.0",
+		Channels:  []string{},
+		PinReason: "{broken} release",
+		Files:     []*listFile{{Name: "test-{version}", Size: 100}},
+	}}
+
+	recordsData, err := yaml.Marshal(records)
+
+	if err != nil {
+		t.Fatalf("Can't encode records: %v", err)
+	}
+
+	var decoded []*listRecord
+
+	err = yaml.Unmarshal(recordsData, &decoded)
+
+	if err != nil {
+		t.Fatalf("Can't decode records: %v", err)
+	}
+
+	switch {
+	case len(decoded) != 1:
+		t.Fatalf("Decoded %d records, want 1", len(decoded))
+	case decoded[0].Name != records[0].Name,
+		decoded[0].PinReason != records[0].PinReason,
+		len(decoded[0].Files) != 1,
+		decoded[0].Files[0].Name != records[0].Files[0].Name:
+		t.Errorf("Decoded record %+v doesn't match original record", decoded[0])
+	}
+}
@@ -0,0 +1,201 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/path"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdPin is "pin" command handler
+func cmdPin(args options.Arguments) error {
+	switch {
+	case !args.Has(0):
+		return fmt.Errorf("You must provide path to data directory")
+	case !args.Has(1):
+		return fmt.Errorf("You must provide name of artefact")
+	case !args.Has(2):
+		return fmt.Errorf("You must provide version of artefact")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+	name := args.Get(1).String()
+	version := args.Get(2).String()
+
+	err := pinArtefact(dataDir, name, version, args.Get(3).String())
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn("{g}Artefact {*}%s{!g} pinned to version {*}%s{!}", name, version)
+
+	return nil
+}
+
+// cmdUnpin is "unpin" command handler
+func cmdUnpin(args options.Arguments) error {
+	switch {
+	case !args.Has(0):
+		return fmt.Errorf("You must provide path to data directory")
+	case !args.Has(1):
+		return fmt.Errorf("You must provide name of artefact")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+	name := args.Get(1).String()
+	artefactDir := path.Join(dataDir, name)
+
+	state, err := data.ReadState(artefactDir)
+
+	if err != nil {
+		return err
+	} else if !state.IsPinned() {
+		return fmt.Errorf("Artefact %s is not pinned", name)
+	}
+
+	versions := getStoredVersions(dataDir, name)
+
+	if len(versions) == 0 {
+		return fmt.Errorf("There are no versions of %s in data directory", name)
+	}
+
+	state.Pin = nil
+	err = state.Write(artefactDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't save artefact state: %v", err)
+	}
+
+	latest := versions[len(versions)-1]
+	err = updateLatestLink(dataDir, name, latest)
+
+	if err != nil {
+		return err
+	}
+
+	err = updateIndexState(dataDir, name)
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn("{g}Artefact {*}%s{!g} unpinned, the latest version is {*}%s{!}", name, latest)
+
+	return nil
+}
+
+// cmdRollback is "rollback" command handler
+func cmdRollback(args options.Arguments) error {
+	switch {
+	case !args.Has(0):
+		return fmt.Errorf("You must provide path to data directory")
+	case !args.Has(1):
+		return fmt.Errorf("You must provide name of artefact")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+	name := args.Get(1).String()
+	versions := getStoredVersions(dataDir, name)
+	current, _ := os.Readlink(path.Join(dataDir, name, "latest"))
+	currentIndex := slices.Index(versions, path.Base(current))
+
+	if currentIndex == -1 {
+		currentIndex = len(versions) - 1
+	}
+
+	if currentIndex < 1 {
+		return fmt.Errorf("There is no previous version of %s to roll back to", name)
+	}
+
+	version := versions[currentIndex-1]
+	reason := args.Get(2).String()
+
+	if reason == "" {
+		reason = fmt.Sprintf("Rolled back from %s", versions[currentIndex])
+	}
+
+	err := pinArtefact(dataDir, name, version, reason)
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn(
+		"{g}Artefact {*}%s{!g} rolled back from {*}%s{!g} to {*}%s{!g} and pinned{!}",
+		name, versions[currentIndex], version,
+	)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// pinArtefact pins artefact to given version and points link to the latest
+// version to it
+func pinArtefact(dataDir, name, version, reason string) error {
+	artefactDir := path.Join(dataDir, name)
+
+	if !fsutil.IsDir(path.Join(artefactDir, version)) {
+		return fmt.Errorf("There is no version %s of %s in data directory", version, name)
+	}
+
+	state, err := data.ReadState(artefactDir)
+
+	if err != nil {
+		return err
+	}
+
+	state.SetPin(version, reason)
+	err = state.Write(artefactDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't save artefact state: %v", err)
+	}
+
+	err = updateLatestLink(dataDir, name, version)
+
+	if err != nil {
+		return err
+	}
+
+	return updateIndexState(dataDir, name)
+}
+
+// getStoredVersions returns sorted list of stored versions of artefact
+func getStoredVersions(dataDir, name string) []string {
+	index, err := readLocalIndex(dataDir)
+
+	if err != nil {
+		return nil
+	}
+
+	info := index.Find(name)
+
+	if info == nil {
+		return nil
+	}
+
+	var result []string
+
+	for _, version := range info.Versions {
+		result = append(result, version.Version)
+	}
+
+	return result
+}
@@ -0,0 +1,81 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdPromote is "promote" command handler
+func cmdPromote(args options.Arguments) error {
+	switch {
+	case !args.Has(0):
+		return fmt.Errorf("You must provide path to data directory")
+	case !args.Has(1):
+		return fmt.Errorf("You must provide name of artefact")
+	case !args.Has(2):
+		return fmt.Errorf("You must provide version of artefact")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+	name := args.Get(1).String()
+	version := args.Get(2).String()
+	channel := strutil.Q(args.Get(3).String(), data.CHANNEL_STABLE)
+
+	if !fsutil.IsDir(path.Join(dataDir, name, version)) {
+		return fmt.Errorf("There is no version %s of %s in data directory", version, name)
+	}
+
+	err := setArtefactChannel(dataDir, name, channel, version)
+
+	if err != nil {
+		return err
+	}
+
+	err = updateIndexState(dataDir, name)
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn(
+		"{g}Version {*}%s{!g} of {*}%s{!g} promoted to {*}%s{!g} channel{!}",
+		version, name, channel,
+	)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// updateIndexState updates info from artefact state in index
+func updateIndexState(dataDir, name string) error {
+	index, err := readLocalIndex(dataDir)
+
+	if err != nil || index.Schema != data.INDEX_SCHEMA || index.Find(name) == nil {
+		return rebuildIndex(dataDir)
+	}
+
+	err = index.UpdateState(dataDir, name)
+
+	if err != nil {
+		return fmt.Errorf("Can't update index: %v", err)
+	}
+
+	return writeIndex(index, dataDir)
+}
@@ -0,0 +1,50 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/spinner"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdReindex is "reindex" command handler
+func cmdReindex(args options.Arguments) error {
+	if !args.Has(0) {
+		return fmt.Errorf("You must provide path to data directory")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+
+	fmtc.NewLine()
+
+	spinner.Show("Rebuilding index (this may take a while)")
+	index, err := data.BuildIndex(dataDir)
+
+	if err != nil {
+		err = fmt.Errorf("Can't build index: %v", err)
+	} else {
+		err = writeIndex(index, dataDir)
+	}
+
+	spinner.Done(err == nil)
+
+	// Warnings are printed after spinner, so they will not be mixed with
+	// its output
+	printIndexWarnings(index)
+
+	fmtc.NewLine()
+
+	return err
+}
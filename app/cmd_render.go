@@ -0,0 +1,51 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/terminal"
+
+	"github.com/essentialkaos/artefactor/catalog"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdRender is "render" command handler
+func cmdRender(args options.Arguments) error {
+	if !args.Has(0) {
+		return fmt.Errorf("You must provide path to data directory")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+	index, err := readLocalIndex(dataDir)
+
+	if err != nil {
+		return fmt.Errorf("Can't get index data: %v", err)
+	} else if index.IsEmpty() {
+		terminal.Warn("No artefacts found")
+		return nil
+	}
+
+	err = catalog.Render(index, dataDir, options.GetS(OPT_URL))
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn(
+		"{g}Catalog for %d artefacts saved to %s{!}",
+		len(index.Artefacts), path.Join(dataDir, catalog.INDEX_PAGE),
+	)
+
+	return nil
+}
@@ -0,0 +1,268 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/path"
+
+	"github.com/essentialkaos/artefactor/catalog"
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// storageServer is HTTP server for data directory
+type storageServer struct {
+	dataDir string
+
+	index     *data.Index
+	indexData []byte
+	indexGzip []byte
+	indexETag string
+	indexDate time.Time
+
+	mu sync.RWMutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdServe is "serve" command handler
+func cmdServe(args options.Arguments) error {
+	if !args.Has(0) {
+		return fmt.Errorf("You must provide path to data directory")
+	}
+
+	dataDir := args.Get(0).Clean().String()
+	err := fsutil.ValidatePerms("DRX", dataDir)
+
+	if err != nil {
+		return err
+	}
+
+	srv := &storageServer{dataDir: dataDir}
+	err = srv.loadIndex()
+
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", srv.healthHandler)
+	mux.HandleFunc("/index.json", srv.indexHandler)
+	mux.HandleFunc("/", srv.fileHandler)
+
+	fmtc.Printfn(
+		"{*}Serving {?primary}%s{!*} on {?primary}%s{!*}…{!}",
+		dataDir, options.GetS(OPT_LISTEN),
+	)
+
+	server := &http.Server{
+		Addr:              options.GetS(OPT_LISTEN),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return server.ListenAndServe()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// healthHandler is handler for health checks
+func (s *storageServer) healthHandler(rw http.ResponseWriter, r *http.Request) {
+	err := s.checkIndex()
+
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	rw.Write([]byte("OK\n"))
+}
+
+// indexHandler is handler for index requests
+func (s *storageServer) indexHandler(rw http.ResponseWriter, r *http.Request) {
+	err := s.checkIndex()
+
+	if err != nil {
+		http.Error(rw, "Index is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	body, etag := s.indexData, s.indexETag
+
+	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		body, etag = s.indexGzip, strings.TrimSuffix(s.indexETag, `"`)+`-gzip"`
+		rw.Header().Set("Content-Encoding", "gzip")
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("ETag", etag)
+	rw.Header().Set("Vary", "Accept-Encoding")
+
+	// ServeContent handles Range, If-Range and If-None-Match headers
+	http.ServeContent(rw, r, "index.json", s.indexDate, bytes.NewReader(body))
+}
+
+// fileHandler is handler for artefacts files requests
+func (s *storageServer) fileHandler(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(rw, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.checkIndex()
+
+	file, checksum := s.resolveFile(r.URL.Path)
+
+	if file == "" || !fsutil.CheckPerms("FR", file) {
+		http.NotFound(rw, r)
+		return
+	}
+
+	fd, err := os.Open(file)
+
+	if err != nil {
+		http.NotFound(rw, r)
+		return
+	}
+
+	defer fd.Close()
+
+	modTime, _ := fsutil.GetMTime(file)
+
+	if checksum != "" {
+		rw.Header().Set("ETag", `"`+checksum+`"`)
+	} else {
+		rw.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, fsutil.GetSize(file), modTime.UnixNano()))
+	}
+
+	// ServeContent handles Range, If-Range and If-None-Match headers
+	http.ServeContent(rw, r, path.Base(file), modTime, fd)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// resolveFile returns path to file in data directory and its checksum from index
+func (s *storageServer) resolveFile(urlPath string) (string, string) {
+	urlPath = path.Clean("/" + urlPath)
+
+	if urlPath == "/" {
+		urlPath = "/" + catalog.INDEX_PAGE
+	}
+
+	parts := strings.Split(strings.TrimPrefix(urlPath, "/"), "/")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(parts) != 3 {
+		return path.Join(s.dataDir, urlPath), ""
+	}
+
+	info := s.index.Find(parts[0])
+
+	if info == nil {
+		return path.Join(s.dataDir, urlPath), ""
+	}
+
+	if parts[1] == "latest" && info.Latest() != nil {
+		parts[1] = info.Latest().Version
+	}
+
+	file := path.Join(s.dataDir, info.Name, parts[1], parts[2])
+	version := info.Find(parts[1])
+
+	if version == nil {
+		return file, ""
+	}
+
+	for _, f := range version.Files {
+		if f.Name == parts[2] {
+			return file, f.SHA256
+		}
+	}
+
+	return file, ""
+}
+
+// checkIndex rereads index if it was modified
+func (s *storageServer) checkIndex() error {
+	modTime, err := fsutil.GetMTime(path.Join(s.dataDir, "index.json"))
+
+	if err != nil {
+		return fmt.Errorf("Can't read index: %v", err)
+	}
+
+	s.mu.RLock()
+	isModified := !modTime.Equal(s.indexDate)
+	s.mu.RUnlock()
+
+	if !isModified {
+		return nil
+	}
+
+	return s.loadIndex()
+}
+
+// loadIndex reads index and prepares its data for serving
+func (s *storageServer) loadIndex() error {
+	indexFile := path.Join(s.dataDir, "index.json")
+	modTime, err := fsutil.GetMTime(indexFile)
+
+	if err != nil {
+		return fmt.Errorf("Can't read index: %v", err)
+	}
+
+	indexData, err := os.ReadFile(indexFile)
+
+	if err != nil {
+		return fmt.Errorf("Can't read index: %v", err)
+	}
+
+	index := &data.Index{}
+	err = json.Unmarshal(indexData, index)
+
+	if err != nil {
+		return fmt.Errorf("Can't decode index: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	gw := gzip.NewWriter(&buf)
+	gw.Write(indexData)
+	gw.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.index = index
+	s.indexData = indexData
+	s.indexGzip = buf.Bytes()
+	s.indexETag = fmt.Sprintf(`"%x"`, sha256.Sum256(indexData))
+	s.indexDate = modTime
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package app
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/httputil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/spinner"
+
+	"github.com/essentialkaos/artefactor/data"
+	"github.com/essentialkaos/artefactor/github"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdValidate is "validate" command handler
+func cmdValidate(args options.Arguments) error {
+	var sources []string
+
+	for _, arg := range args {
+		sources = append(sources, arg.String())
+	}
+
+	if len(sources) == 0 {
+		sources = options.Split(OPT_SOURCES)
+	}
+
+	artefacts, problems := data.LintArtefacts(sources...)
+
+	if options.GetB(OPT_ONLINE) && len(artefacts) != 0 {
+		problems = append(problems, checkArtefactsOnline(artefacts)...)
+	}
+
+	if len(problems) == 0 {
+		fmtc.Printfn(
+			"{g}Sources are valid {s-}(%s artefacts){!}",
+			fmtutil.PrettyNum(len(artefacts)),
+		)
+		return nil
+	}
+
+	fmtc.NewLine()
+
+	for _, problem := range problems {
+		fmtc.Printfn(" {s-}•{!} %v", problem)
+	}
+
+	fmtc.NewLine()
+
+	return fmt.Errorf("Found %s problems in sources", fmtutil.PrettyNum(len(problems)))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkArtefactsOnline checks artefacts source patterns against assets of the
+// latest releases
+func checkArtefactsOnline(artefacts data.Artefacts) []error {
+	var problems []error
+
+	setRepoTokens(artefacts)
+
+	spinner.Show("Checking sources on GitHub")
+
+	for i, artefact := range artefacts {
+		if artefact.Repo == "" || artefact.Source == "" || httputil.IsURL(artefact.Source) ||
+			(artefact.Provider != data.PROVIDER_RELEASES && artefact.Provider != "") {
+			continue
+		}
+
+		spinner.Update(
+			"{s}[%d/%d]{!} Checking {?primary}%s{!}",
+			i+1, len(artefacts), artefact.Name,
+		)
+
+		version, _, err := github.GetLatestReleaseVersion(artefact.Repo)
+
+		if err != nil {
+			problems = append(problems, fmt.Errorf(
+				"%s: artefact %q: can't get info about the latest release: %v",
+				artefact.Position(), artefact.Name, err,
+			))
+			continue
+		}
+
+		// Use copy of artefact to keep original patterns untouched
+		a := artefact.Clone()
+		a.ApplyVersion(version)
+
+		_, err = getArtefactBinaryURL(a)
+
+		if err != nil {
+			problems = append(problems, fmt.Errorf(
+				"%s: artefact %q: source %q doesn't match any asset of release %s",
+				artefact.Position(), artefact.Name, artefact.Source, version,
+			))
+		}
+	}
+
+	spinner.Update("Check sources on GitHub")
+	spinner.Done(len(problems) == 0)
+
+	return problems
+}
@@ -0,0 +1,157 @@
+package binary
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Info contains info about ELF binary
+type Info struct {
+	Arch        string `json:"arch"`
+	Interpreter string `json:"interpreter,omitempty"`
+	Static      bool   `json:"static"`
+	Stripped    bool   `json:"stripped"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// archNames contains names of supported architectures
+var archNames = map[elf.Machine]string{
+	elf.EM_386:       "i386",
+	elf.EM_X86_64:    "x86_64",
+	elf.EM_ARM:       "arm",
+	elf.EM_AARCH64:   "aarch64",
+	elf.EM_PPC64:     "ppc64",
+	elf.EM_S390:      "s390x",
+	elf.EM_RISCV:     "riscv64",
+	elf.EM_LOONGARCH: "loongarch64",
+}
+
+// archAliases contains aliases for architecture names
+var archAliases = map[string]string{
+	"amd64":   "x86_64",
+	"x64":     "x86_64",
+	"386":     "i386",
+	"i686":    "i386",
+	"x86":     "i386",
+	"arm64":   "aarch64",
+	"armv7":   "arm",
+	"armhf":   "arm",
+	"ppc64el": "ppc64le",
+	"loong64": "loongarch64",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsELF returns true if given file is an ELF binary
+func IsELF(file string) bool {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return false
+	}
+
+	defer fd.Close()
+
+	magic := make([]byte, len(elf.ELFMAG))
+	_, err = fd.Read(magic)
+
+	return err == nil && bytes.Equal(magic, []byte(elf.ELFMAG))
+}
+
+// Inspect reads info about given ELF binary
+func Inspect(file string) (*Info, error) {
+	bin, err := elf.Open(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read ELF data: %v", err)
+	}
+
+	defer bin.Close()
+
+	info := &Info{
+		Arch:     getArchName(bin),
+		Static:   true,
+		Stripped: bin.Section(".symtab") == nil,
+	}
+
+	for _, prog := range bin.Progs {
+		if prog.Type != elf.PT_INTERP {
+			continue
+		}
+
+		interp := make([]byte, prog.Filesz)
+		_, err = prog.ReadAt(interp, 0)
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't read interpreter info: %v", err)
+		}
+
+		info.Interpreter = strings.TrimRight(string(interp), "\x00")
+		info.Static = false
+	}
+
+	libs, _ := bin.ImportedLibraries()
+
+	if len(libs) != 0 {
+		info.Static = false
+	}
+
+	return info, nil
+}
+
+// NormalizeArch returns canonical name of architecture
+func NormalizeArch(arch string) string {
+	arch = strings.ToLower(arch)
+
+	if archAliases[arch] != "" {
+		return archAliases[arch]
+	}
+
+	return arch
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Linkage returns short description of binary linkage
+func (i *Info) Linkage() string {
+	switch {
+	case i == nil:
+		return ""
+	case i.Static:
+		return "static"
+	case i.Interpreter != "":
+		return "dynamic:" + i.Interpreter[strings.LastIndex(i.Interpreter, "/")+1:]
+	}
+
+	return "dynamic"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getArchName returns name of binary architecture
+func getArchName(bin *elf.File) string {
+	if bin.Machine == elf.EM_PPC64 && bin.Data == elf.ELFDATA2LSB {
+		return "ppc64le"
+	}
+
+	name, ok := archNames[bin.Machine]
+
+	if !ok {
+		return strings.ToLower(strings.TrimPrefix(bin.Machine.String(), "EM_"))
+	}
+
+	return name
+}
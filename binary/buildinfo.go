@@ -0,0 +1,59 @@
+package binary
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"debug/buildinfo"
+	"fmt"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// BuildInfo contains info about Go binary build
+type BuildInfo struct {
+	GoVersion   string    `json:"go_version"`
+	Main        string    `json:"main,omitempty"`
+	MainVersion string    `json:"main_version,omitempty"`
+	Modules     []*Module `json:"modules,omitempty"`
+}
+
+// Module contains info about Go module
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadBuildInfo reads Go build info from given binary
+func ReadBuildInfo(file string) (*BuildInfo, error) {
+	bi, err := buildinfo.ReadFile(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read Go build info: %v", err)
+	}
+
+	info := &BuildInfo{
+		GoVersion:   bi.GoVersion,
+		Main:        bi.Main.Path,
+		MainVersion: bi.Main.Version,
+	}
+
+	for _, dep := range bi.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+
+		info.Modules = append(info.Modules, &Module{
+			Path:    dep.Path,
+			Version: dep.Version,
+		})
+	}
+
+	return info, nil
+}
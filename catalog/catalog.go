@@ -0,0 +1,163 @@
+package catalog
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// INDEX_PAGE is name of catalog main page
+const INDEX_PAGE = "index.html"
+
+// DEFAULT_STORAGE is storage placeholder used in commands if storage URL is
+// not set
+const DEFAULT_STORAGE = "<storage>"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// indexPage contains data for catalog main page
+type indexPage struct {
+	Storage   string
+	Generated time.Time
+	Artefacts []*data.ArtefactInfo
+}
+
+// artefactPage contains data for artefact page
+type artefactPage struct {
+	Storage   string
+	Generated time.Time
+	Artefact  *data.ArtefactInfo
+	Versions  []*data.ArtefactVersion
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// templates contains parsed catalog templates
+var templates = template.Must(
+	template.New("").Funcs(template.FuncMap{
+		"size":  func(size int64) string { return fmtutil.PrettySize(size) },
+		"date":  formatDate,
+		"short": func(s string) string { return s[:min(len(s), 12)] },
+	}).Parse(pageTemplates),
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Render renders HTML catalog for given index into data directory
+func Render(index *data.Index, dir, storage string) error {
+	if index.IsEmpty() {
+		return fmt.Errorf("Index is empty")
+	}
+
+	storage = strings.TrimRight(storage, "/")
+
+	if storage == "" {
+		storage = DEFAULT_STORAGE
+	}
+
+	now := time.Now()
+
+	err := renderPage(path.Join(dir, INDEX_PAGE), "index", &indexPage{
+		Storage:   storage,
+		Generated: now,
+		Artefacts: index.Artefacts,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	pagesDir := path.Join(dir, data.CATALOG_DIR)
+	err = os.MkdirAll(pagesDir, 0755)
+
+	if err != nil {
+		return fmt.Errorf("Can't create directory for catalog pages: %v", err)
+	}
+
+	for _, info := range index.Artefacts {
+		versions := slices.Clone(info.Versions)
+		slices.Reverse(versions)
+
+		err = renderPage(path.Join(pagesDir, info.Name+".html"), "artefact", &artefactPage{
+			Storage:   storage,
+			Generated: now,
+			Artefact:  info,
+			Versions:  versions,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return pruneArtefactPages(index, pagesDir)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderPage renders template with given data into file
+func renderPage(file, name string, pageData any) error {
+	var buf bytes.Buffer
+
+	err := templates.ExecuteTemplate(&buf, name, pageData)
+
+	if err != nil {
+		return fmt.Errorf("Can't render page %s: %v", path.Base(file), err)
+	}
+
+	err = os.WriteFile(file, buf.Bytes(), 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't save page %s: %v", path.Base(file), err)
+	}
+
+	return os.Chmod(file, 0644)
+}
+
+// pruneArtefactPages removes pages of artefacts which are not present in index
+func pruneArtefactPages(index *data.Index, pagesDir string) error {
+	pages := fsutil.List(pagesDir, true, fsutil.ListingFilter{MatchPatterns: []string{"*.html"}})
+
+	for _, page := range pages {
+		if index.Find(strings.TrimSuffix(page, ".html")) != nil {
+			continue
+		}
+
+		err := os.Remove(path.Join(pagesDir, page))
+
+		if err != nil {
+			return fmt.Errorf("Can't remove stale page %s: %v", page, err)
+		}
+	}
+
+	return nil
+}
+
+// formatDate formats date for catalog pages
+func formatDate(d time.Time) string {
+	if d.IsZero() {
+		return "—"
+	}
+
+	return timeutil.Format(d.UTC(), "%Y/%m/%d %H:%M UTC")
+}
@@ -0,0 +1,87 @@
+package catalog
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// pageTemplates contains templates of catalog pages
+const pageTemplates = `
+{{- define "header" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{ . }}</title>
+  <style>
+    body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; padding: 0 1em; color: #222; }
+    h1 a { color: inherit; text-decoration: none; }
+    table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+    th, td { text-align: left; padding: 6px 10px; border-bottom: 1px solid #e5e5e5; vertical-align: top; }
+    th { background: #f6f6f6; }
+    code { font-family: ui-monospace, Menlo, Consolas, monospace; font-size: 0.9em; }
+    .muted { color: #888; }
+    .cmd { background: #f6f6f6; padding: 2px 6px; border-radius: 3px; }
+    footer { color: #888; font-size: 0.85em; }
+  </style>
+</head>
+<body>
+{{- end -}}
+
+{{- define "footer" -}}
+<footer>Generated {{ date . }} by artefactor</footer>
+</body>
+</html>
+{{ end -}}
+
+{{- define "index" -}}
+{{ template "header" "Artefacts" }}
+<h1>Artefacts</h1>
+<table>
+  <tr><th>Name</th><th>Latest version</th><th>Published</th><th>Size</th><th>Versions</th></tr>
+  {{- range .Artefacts }}
+  {{- $latest := .Latest }}
+  <tr>
+    <td><a href="catalog/{{ .Name }}.html">{{ .Name }}</a></td>
+    <td>{{ with $latest }}{{ .Version }}{{ end }}</td>
+    <td>{{ with $latest }}{{ date .Published }}{{ end }}</td>
+    <td>{{ with $latest }}{{ size .Size }}{{ end }}</td>
+    <td>{{ len .Versions }}</td>
+  </tr>
+  {{- end }}
+</table>
+{{ template "footer" .Generated }}
+{{- end -}}
+
+{{- define "artefact" -}}
+{{ template "header" .Artefact.Name }}
+<h1><a href="../index.html">Artefacts</a> / {{ .Artefact.Name }}</h1>
+<p>Get the latest version: <code class="cmd">artefactor get {{ .Storage }} {{ .Artefact.Name }}</code></p>
+{{- $storage := .Storage }}
+{{- $name := .Artefact.Name }}
+{{- range .Versions }}
+{{- $version := .Version }}
+<h2>{{ .Version }}</h2>
+<p>
+  Published: {{ date .Published }} &middot; Downloaded: {{ date .Downloaded }} &middot; Size: {{ size .Size }}
+  {{- if .Repo }} &middot; Source: <a href="https://github.com/{{ .Repo }}">{{ .Repo }}</a>{{ if .Tag }} ({{ .Tag }}){{ end }}{{ end }}
+</p>
+<p><code class="cmd">artefactor get {{ $storage }} {{ $name }} {{ .Version }}</code></p>
+<table>
+  <tr><th>File</th><th>Size</th><th>Platform</th><th>SHA-256</th></tr>
+  {{- range .Files }}
+  <tr>
+    <td><a href="../{{ $name }}/{{ $version }}/{{ .Name }}">{{ .Name }}</a></td>
+    <td>{{ size .Size }}</td>
+    <td>{{ if .Platform }}{{ .Platform }}{{ else }}<span class="muted">—</span>{{ end }}</td>
+    <td>{{ if .SHA256 }}<code title="{{ .SHA256 }}">{{ short .SHA256 }}…</code>{{ else }}<span class="muted">—</span>{{ end }}</td>
+  </tr>
+  {{- end }}
+</table>
+{{- end }}
+{{ template "footer" .Generated }}
+{{- end -}}
+`
@@ -9,47 +9,112 @@ package data
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/httputil"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/strutil"
 
 	simpleyaml "github.com/essentialkaos/go-simpleyaml/v2"
+
+	"github.com/essentialkaos/artefactor/vercmp"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Artefact contains info about artefact
 type Artefact struct {
-	Name   string
-	Repo   string
-	Output string
-	Source string
-	File   string
-	Dir    string
+	Name     string
+	Repo     string
+	Provider string
+	Output   string
+	Source   string
+	File     string
+	Dir      string
+	Arch     string
+	OS       string
+	Token    string
+	Ordering string
+
+	Mirror bool
+	Assets []string
 
-	index int
+	Test    *ArtefactTest
+	Actions *ArtefactActions
+	Build   *ArtefactBuild
+
+	index   int
+	file    string
+	line    int
+	remote  bool
+	unknown []string
+}
+
+// ArtefactTest contains smoke test configuration
+type ArtefactTest struct {
+	Args    []string
+	Expect  string
+	Timeout time.Duration
+}
+
+// ArtefactActions contains GitHub Actions artifact configuration
+type ArtefactActions struct {
+	Workflow string
+	Branch   string
+	Artifact string
+	Version  string
+}
+
+// ArtefactBuild contains build-from-source recipe
+type ArtefactBuild struct {
+	Commands []string
+	Env      map[string]string
+	Timeout  time.Duration
 }
 
 // Artefacts is a slice with artefacts
 type Artefacts []*Artefact
 
+// artefactYaml is artefact definition with layers of inherited properties
+// (artefact → template → defaults)
+type artefactYaml []*simpleyaml.Yaml
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// ReadArtefacts reads YAML-encoded artefacts list
-func ReadArtefacts(file string) (Artefacts, error) {
-	yamlData, err := os.ReadFile(file)
+// interpolationRegex is regexp for ${ENV_VAR} and ${file:/path} references
+var interpolationRegex = regexp.MustCompile(`\$\{([^{}]+)\}`)
 
-	if err != nil {
-		return nil, fmt.Errorf("Error while reading artefacts data: %v", err)
-	}
+// ////////////////////////////////////////////////////////////////////////////////// //
 
-	yaml, err := simpleyaml.NewYaml(yamlData)
+// DEFAULT_TEST_TIMEOUT is default timeout for smoke test
+const DEFAULT_TEST_TIMEOUT = 10 * time.Second
 
-	if err != nil {
-		return nil, fmt.Errorf("Error while parsing artefacts data: %v", err)
-	}
+// DEFAULT_BUILD_TIMEOUT is default timeout for building artefact from source
+const DEFAULT_BUILD_TIMEOUT = 10 * time.Minute
 
-	return convertArtefactsYaml(yaml)
-}
+// DEFAULT_OS is default artefact OS
+const DEFAULT_OS = "linux"
+
+const (
+	PROVIDER_RELEASES = "releases"
+	PROVIDER_TAGS     = "tags"
+	PROVIDER_ACTIONS  = "actions"
+)
+
+const (
+	SOURCE_TARBALL = "tarball"
+	SOURCE_ZIPBALL = "zipball"
+)
+
+const (
+	ACTIONS_VERSION_RUN = "run"
+	ACTIONS_VERSION_SHA = "sha"
+)
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
@@ -66,28 +131,188 @@ func (a Artefacts) Validate() error {
 	return nil
 }
 
+// Position returns position of artefact definition in sources file
+func (a *Artefact) Position() string {
+	switch {
+	case a.file == "":
+		return fmt.Sprintf("#%d", a.index)
+	case a.line == 0:
+		return fmt.Sprintf("%s:#%d", a.file, a.index)
+	}
+
+	return fmt.Sprintf("%s:%d", a.file, a.line)
+}
+
+// IsRemote returns true if artefact is defined in remote sources file
+func (a *Artefact) IsRemote() bool {
+	return a.remote
+}
+
+// IsSourceArchive returns true if artefact source is auto-generated source
+// archive of tag
+func (a *Artefact) IsSourceArchive() bool {
+	return a.Source == SOURCE_TARBALL || a.Source == SOURCE_ZIPBALL
+}
+
+// IsMirror returns true if artefact is a mirror of release assets
+func (a *Artefact) IsMirror() bool {
+	return a.Mirror || len(a.Assets) != 0
+}
+
+// IsMatchAsset returns true if given asset must be mirrored
+func (a *Artefact) IsMatchAsset(name string) bool {
+	if a.Mirror && len(a.Assets) == 0 {
+		return true
+	}
+
+	for _, pattern := range a.Assets {
+		isMatch, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+
+		if isMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StorageDir returns name of artefact directory in data directory
+func (a *Artefact) StorageDir() string {
+	return strutil.Q(a.Dir, a.Name)
+}
+
+// Target returns path to output file relative to version directory parent
+func (a *Artefact) Target() string {
+	return a.StorageDir() + "/" + a.Output
+}
+
 // Validate validates artefact info
 func (a *Artefact) Validate() error {
+	problems := a.Check()
+
 	switch {
+	case len(problems) == 0:
+		return nil
 	case a.Name == "":
-		return fmt.Errorf("Artefact %d invalid: name can't be empty", a.index)
+		return fmt.Errorf("Artefact %s invalid: %s", a.Position(), problems[0])
+	}
+
+	return fmt.Errorf("Artefact %q invalid: %s", a.Name, problems[0])
+}
+
+// Check checks artefact info and returns all found problems
+func (a *Artefact) Check() []string {
+	var problems []string
+
+	if a.Name == "" {
+		problems = append(problems, "name can't be empty")
+	}
+
+	switch {
 	case a.Repo == "":
-		return fmt.Errorf("Artefact %q invalid: repo can't be empty", a.Name)
-	case a.Source == "":
-		return fmt.Errorf("Artefact %q invalid: source can't be empty", a.Name)
-	case a.Output == "":
-		return fmt.Errorf("Artefact %q invalid: output can't be empty", a.Name)
-	case a.Dir != "" && strings.Contains(a.Dir, "/"):
-		return fmt.Errorf("Artefact %q invalid: dir must not contains /", a.Name)
-	case a.Repo != "" && !strings.Contains(a.Repo, "/"):
-		return fmt.Errorf("Artefact %q invalid: repo name is invalid", a.Name)
-	case a.File == "" && strings.HasSuffix(a.Source, ".tar.gz"),
-		a.File == "" && strings.HasSuffix(a.Source, ".tar.xz"),
-		a.File == "" && strings.HasSuffix(a.Source, ".zip"):
-		return fmt.Errorf("Artefact %q invalid: file is not defined for archive file", a.Name)
+		problems = append(problems, "repo can't be empty")
+	case !strings.Contains(a.Repo, "/"):
+		problems = append(problems, "repo name is invalid")
 	}
 
-	return nil
+	if !a.IsMirror() && a.Source == "" && a.Provider != PROVIDER_ACTIONS && a.Build == nil {
+		problems = append(problems, "source can't be empty")
+	}
+
+	// Artefacts without provider are downloaded from releases
+	switch a.Provider {
+	case PROVIDER_RELEASES, "":
+		if a.IsSourceArchive() {
+			problems = append(problems, fmt.Sprintf("source %q can be used only with tags provider", a.Source))
+		}
+	case PROVIDER_TAGS:
+		if a.IsMirror() {
+			problems = append(problems, "mirror mode can't be used with tags provider")
+		}
+
+		if a.Source != "" && !a.IsSourceArchive() && !httputil.IsURL(a.Source) {
+			problems = append(problems, "source must be URL, tarball or zipball for tags provider")
+		}
+	case PROVIDER_ACTIONS:
+		problems = append(problems, a.checkActions()...)
+	default:
+		problems = append(problems, fmt.Sprintf("unknown provider %q", a.Provider))
+	}
+
+	if !a.IsMirror() && a.Output == "" {
+		problems = append(problems, "output can't be empty")
+	}
+
+	if a.Dir != "" && strings.Contains(a.Dir, "/") {
+		problems = append(problems, "dir must not contains /")
+	}
+
+	if a.StorageDir() == CATALOG_DIR {
+		problems = append(problems, fmt.Sprintf("%q is reserved for catalog pages, set different dir", CATALOG_DIR))
+	}
+
+	if a.File == "" && (strings.HasSuffix(a.Source, ".tar.gz") ||
+		strings.HasSuffix(a.Source, ".tar.xz") ||
+		strings.HasSuffix(a.Source, ".zip")) {
+		problems = append(problems, "file is not defined for archive file")
+	}
+
+	if strings.Contains(a.Output+a.Source+a.File+a.Dir, "{arch}") {
+		problems = append(problems, "{arch} is used, but arch is not defined")
+	}
+
+	if !vercmp.IsValidOrdering(a.Ordering) {
+		problems = append(problems, fmt.Sprintf(
+			"unknown ordering %q (supported: %s)",
+			a.Ordering, strings.Join(vercmp.Orderings, ", "),
+		))
+	}
+
+	problems = append(problems, a.checkPatterns()...)
+
+	if a.Build != nil {
+		problems = append(problems, a.checkBuild()...)
+	}
+
+	if a.Test != nil {
+		_, err := regexp.Compile(strings.ReplaceAll(a.Test.Expect, "{version}", "0.0.0"))
+
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("test expect is not a valid regexp: %v", err))
+		}
+	}
+
+	return problems
+}
+
+// Clone returns deep copy of artefact
+func (a *Artefact) Clone() *Artefact {
+	if a == nil {
+		return nil
+	}
+
+	c := *a
+	c.unknown = slices.Clone(a.unknown)
+
+	if a.Test != nil {
+		test := *a.Test
+		test.Args = slices.Clone(a.Test.Args)
+		c.Test = &test
+	}
+
+	if a.Actions != nil {
+		actions := *a.Actions
+		c.Actions = &actions
+	}
+
+	if a.Build != nil {
+		build := *a.Build
+		build.Commands = slices.Clone(a.Build.Commands)
+		build.Env = maps.Clone(a.Build.Env)
+		c.Build = &build
+	}
+
+	return &c
 }
 
 // ApplyVersion applies version data to artefact
@@ -99,35 +324,450 @@ func (a *Artefact) ApplyVersion(version string) {
 	if strings.Contains(a.Source, "{version}") {
 		a.Source = strings.ReplaceAll(a.Source, "{version}", version)
 	}
+
+	if a.Build != nil {
+		for i := range a.Build.Commands {
+			a.Build.Commands[i] = strings.ReplaceAll(a.Build.Commands[i], "{version}", version)
+		}
+	}
+
+	if a.Test != nil && strings.Contains(a.Test.Expect, "{version}") {
+		a.Test.Expect = strings.ReplaceAll(a.Test.Expect, "{version}", regexp.QuoteMeta(version))
+	}
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // convertArtefactsYaml converts yaml data into internal struct
-func convertArtefactsYaml(yaml *simpleyaml.Yaml) (Artefacts, error) {
+func convertArtefactsYaml(yaml *simpleyaml.Yaml, isRemote bool) (Artefacts, error) {
+	var defaults, templates *simpleyaml.Yaml
+
+	if yaml.IsMap() {
+		// File may contain only includes
+		if !yaml.IsExist("artefacts") {
+			return nil, nil
+		}
+
+		defaults, templates = yaml.Get("defaults"), yaml.Get("templates")
+		yaml = yaml.Get("artefacts")
+	}
+
 	if !yaml.IsArray() {
-		return nil, fmt.Errorf("Wrong YAML format (must be array)")
+		return nil, fmt.Errorf("Wrong YAML format (must be array or map with artefacts)")
 	}
 
 	var index int
 	var result Artefacts
 
 	for yaml.IsIndexExist(index) {
-		info := yaml.GetByIndex(index)
+		item := yaml.GetByIndex(index)
+		info := artefactYaml{item}
+		extend := info.Get("extend").MustString("")
 
-		result = append(result, &Artefact{
-			Name:   info.Get("name").MustString(""),
-			Repo:   info.Get("repo").MustString(""),
-			Output: info.Get("output").MustString(""),
-			Source: info.Get("source").MustString(""),
-			File:   info.Get("file").MustString(""),
-			Dir:    info.Get("dir").MustString(""),
+		if extend != "" {
+			if templates == nil || !templates.IsExist(extend) {
+				return nil, fmt.Errorf("Artefact #%d uses unknown template %q", index, extend)
+			}
 
-			index: index,
-		})
+			info = append(info, templates.Get(extend))
+		}
+
+		if defaults != nil {
+			info = append(info, defaults)
+		}
+
+		artefact := &Artefact{
+			Name:     info.Get("name").MustString(""),
+			Repo:     info.Get("repo").MustString(""),
+			Provider: info.Get("provider").MustString(PROVIDER_RELEASES),
+			Output:   info.Get("output").MustString(""),
+			Source:   info.Get("source").MustString(""),
+			File:     info.Get("file").MustString(""),
+			Dir:      info.Get("dir").MustString(""),
+			Arch:     info.Get("arch").MustString(""),
+			OS:       info.Get("os").MustString(DEFAULT_OS),
+			Token:    info.Get("token").MustString(""),
+			Ordering: info.Get("ordering").MustString(""),
+			Mirror:   info.Get("mirror").MustBool(false),
+			Assets:   info.Get("assets").MustStringArray(nil),
+
+			Test:    convertArtefactTestYaml(info.Get("test")),
+			Actions: convertArtefactActionsYaml(info.Get("actions")),
+			Build:   convertArtefactBuildYaml(info.Get("build")),
+
+			index:   index,
+			remote:  isRemote,
+			unknown: findUnknownKeys(item, artefactKeys, ""),
+		}
+
+		if item.IsExist("test") {
+			artefact.unknown = append(
+				artefact.unknown,
+				findUnknownKeys(item.Get("test"), testKeys, "test.")...,
+			)
+		}
+
+		if item.IsExist("actions") {
+			artefact.unknown = append(
+				artefact.unknown,
+				findUnknownKeys(item.Get("actions"), actionsKeys, "actions.")...,
+			)
+		}
+
+		if item.IsExist("build") {
+			artefact.unknown = append(
+				artefact.unknown,
+				findUnknownKeys(item.Get("build"), buildKeys, "build.")...,
+			)
+		}
+
+		err := artefact.interpolate()
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't interpolate artefact #%d properties: %v", index, err)
+		}
+
+		artefact.applyPlaceholders()
+
+		result = append(result, artefact)
 
 		index++
 	}
 
 	return result, nil
 }
+
+// convertArtefactTestYaml converts yaml data with smoke test info
+func convertArtefactTestYaml(test *simpleyaml.Yaml) *ArtefactTest {
+	if !test.IsMap() {
+		return nil
+	}
+
+	timeout := test.Get("timeout").MustInt(0)
+
+	result := &ArtefactTest{
+		Args:    test.Get("args").MustStringArray(nil),
+		Expect:  test.Get("expect").MustString(""),
+		Timeout: time.Duration(timeout) * time.Second,
+	}
+
+	if result.Timeout <= 0 {
+		result.Timeout = DEFAULT_TEST_TIMEOUT
+	}
+
+	return result
+}
+
+// convertArtefactActionsYaml converts yaml data with GitHub Actions artifact info
+func convertArtefactActionsYaml(actions *simpleyaml.Yaml) *ArtefactActions {
+	if !actions.IsMap() {
+		return nil
+	}
+
+	return &ArtefactActions{
+		Workflow: actions.Get("workflow").MustString(""),
+		Branch:   actions.Get("branch").MustString(""),
+		Artifact: actions.Get("artifact").MustString(""),
+		Version:  actions.Get("version").MustString(ACTIONS_VERSION_RUN),
+	}
+}
+
+// convertArtefactBuildYaml converts yaml data with build recipe
+func convertArtefactBuildYaml(build *simpleyaml.Yaml) *ArtefactBuild {
+	if !build.IsMap() {
+		return nil
+	}
+
+	timeout := build.Get("timeout").MustInt(0)
+
+	result := &ArtefactBuild{
+		Commands: build.Get("commands").MustStringArray(nil),
+		Env:      map[string]string{},
+		Timeout:  time.Duration(timeout) * time.Second,
+	}
+
+	envKeys, _ := build.Get("env").GetMapKeys()
+
+	for _, key := range envKeys {
+		result.Env[key] = build.Get("env").Get(key).MustString("")
+	}
+
+	if result.Timeout <= 0 {
+		result.Timeout = DEFAULT_BUILD_TIMEOUT
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns value of the given property from the first layer which contains it
+func (a artefactYaml) Get(key string) *simpleyaml.Yaml {
+	for _, layer := range a {
+		if layer.IsExist(key) {
+			return layer.Get(key)
+		}
+	}
+
+	return a[0].Get(key)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// toYAML renders artefact definition as YAML list item
+func (a *Artefact) toYAML(indent string) string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "%s- name: %q\n", indent, a.Name)
+	fmt.Fprintf(&buf, "%s  repo: %q\n", indent, a.Repo)
+
+	for _, prop := range [][2]string{
+		{"dir", a.Dir},
+		{"source", a.Source},
+		{"file", a.File},
+		{"output", a.Output},
+		{"arch", a.Arch},
+	} {
+		if prop[1] != "" {
+			fmt.Fprintf(&buf, "%s  %s: %q\n", indent, prop[0], prop[1])
+		}
+	}
+
+	if a.OS != "" && a.OS != DEFAULT_OS {
+		fmt.Fprintf(&buf, "%s  os: %q\n", indent, a.OS)
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// checkPatterns checks source and file glob patterns
+func (a *Artefact) checkPatterns() []string {
+	var problems []string
+
+	if a.Source != "" && (a.Provider == PROVIDER_RELEASES || a.Provider == "") && !httputil.IsURL(a.Source) {
+		source := strings.ReplaceAll(a.Source, "{version}", "0")
+
+		if strings.Contains(source, "/") {
+			problems = append(problems, "source pattern contains / and will never match asset name")
+		} else if _, err := path.Match(source, ""); err != nil {
+			problems = append(problems, fmt.Sprintf("source pattern %q is malformed", a.Source))
+		}
+	}
+
+	if a.File != "" {
+		_, err := path.Match(strings.ReplaceAll(a.File, "{version}", "0"), "")
+
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("file pattern %q is malformed", a.File))
+		}
+	}
+
+	for _, pattern := range a.Assets {
+		_, err := path.Match(pattern, "")
+
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("assets pattern %q is malformed", pattern))
+		}
+	}
+
+	return problems
+}
+
+// checkActions checks GitHub Actions artifact configuration
+func (a *Artefact) checkActions() []string {
+	var problems []string
+
+	if a.IsMirror() {
+		problems = append(problems, "mirror mode can't be used with actions provider")
+	}
+
+	if a.Source != "" {
+		problems = append(problems, "source can't be used with actions provider")
+	}
+
+	if a.Actions == nil {
+		return append(problems, "actions section is required for actions provider")
+	}
+
+	if a.Actions.Workflow == "" {
+		problems = append(problems, "actions workflow can't be empty")
+	}
+
+	if a.Actions.Artifact == "" {
+		problems = append(problems, "actions artifact can't be empty")
+	}
+
+	if a.File == "" {
+		problems = append(problems, "file is not defined for actions artifact")
+	}
+
+	switch a.Actions.Version {
+	case ACTIONS_VERSION_RUN, ACTIONS_VERSION_SHA:
+		// ok
+	default:
+		problems = append(problems, fmt.Sprintf(
+			"unknown actions version mode %q (must be %q or %q)",
+			a.Actions.Version, ACTIONS_VERSION_RUN, ACTIONS_VERSION_SHA,
+		))
+	}
+
+	return problems
+}
+
+// checkBuild checks build recipe
+func (a *Artefact) checkBuild() []string {
+	var problems []string
+
+	if a.IsMirror() {
+		problems = append(problems, "mirror mode can't be used with build recipe")
+	}
+
+	if a.Provider == PROVIDER_ACTIONS {
+		problems = append(problems, "build recipe can't be used with actions provider")
+	}
+
+	if a.Source != "" {
+		problems = append(problems, "source can't be used with build recipe")
+	}
+
+	if len(a.Build.Commands) == 0 {
+		problems = append(problems, "build commands can't be empty")
+	}
+
+	hasOutput := slices.ContainsFunc(a.Build.Commands, func(command string) bool {
+		return strings.Contains(command, "{output}")
+	})
+
+	if !hasOutput {
+		problems = append(problems, "none of build commands uses {output}")
+	}
+
+	return problems
+}
+
+// interpolate replaces ${ENV_VAR} and ${file:/path} references in artefact
+// properties. References are not allowed in remote sources files, because
+// such files can send secrets to any host.
+func (a *Artefact) interpolate() error {
+	var err error
+
+	props := []*string{
+		&a.Name, &a.Repo, &a.Output, &a.Source, &a.File, &a.Dir, &a.Arch, &a.OS, &a.Token,
+	}
+
+	if a.Actions != nil {
+		props = append(props, &a.Actions.Workflow, &a.Actions.Branch, &a.Actions.Artifact)
+	}
+
+	for _, prop := range props {
+		*prop, err = a.interpolateValue(*prop)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if a.Build != nil {
+		for key, value := range a.Build.Env {
+			a.Build.Env[key], err = a.interpolateValue(value)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// interpolateValue replaces references in given artefact property value
+func (a *Artefact) interpolateValue(value string) (string, error) {
+	if a.remote && interpolationRegex.MatchString(value) {
+		return "", fmt.Errorf(
+			"References %s are not allowed in remote sources files",
+			strings.Join(interpolationRegex.FindAllString(value, -1), ", "),
+		)
+	}
+
+	return interpolateValue(value)
+}
+
+// applyPlaceholders replaces {name}, {arch} and {os} placeholders in artefact
+// properties
+func (a *Artefact) applyPlaceholders() {
+	replacer := strings.NewReplacer(getPlaceholdersPairs(a)...)
+
+	a.Output = replacer.Replace(a.Output)
+	a.Source = replacer.Replace(a.Source)
+	a.File = replacer.Replace(a.File)
+	a.Dir = replacer.Replace(a.Dir)
+
+	if a.Actions != nil {
+		a.Actions.Artifact = replacer.Replace(a.Actions.Artifact)
+	}
+
+	if a.Build != nil {
+		for i := range a.Build.Commands {
+			a.Build.Commands[i] = replacer.Replace(a.Build.Commands[i])
+		}
+	}
+
+	if a.Test != nil {
+		a.Test.Expect = replacer.Replace(a.Test.Expect)
+
+		for i := range a.Test.Args {
+			a.Test.Args[i] = replacer.Replace(a.Test.Args[i])
+		}
+	}
+}
+
+// getPlaceholdersPairs returns old/new pairs for defined placeholders
+func getPlaceholdersPairs(a *Artefact) []string {
+	var result []string
+
+	for placeholder, value := range map[string]string{
+		"{name}": a.Name,
+		"{arch}": a.Arch,
+		"{os}":   a.OS,
+	} {
+		if value != "" {
+			result = append(result, placeholder, value)
+		}
+	}
+
+	return result
+}
+
+// interpolateValue replaces ${ENV_VAR} and ${file:/path} references in given
+// value
+func interpolateValue(value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var err error
+
+	result := interpolationRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		name := ref[2 : len(ref)-1]
+
+		if strings.HasPrefix(name, "file:") {
+			data, readErr := os.ReadFile(strings.TrimPrefix(name, "file:"))
+
+			if readErr != nil && err == nil {
+				err = fmt.Errorf("Can't read %s: %v", ref, readErr)
+			}
+
+			return strings.TrimSpace(string(data))
+		}
+
+		envValue, ok := os.LookupEnv(name)
+
+		if !ok && err == nil {
+			err = fmt.Errorf("Environment variable %s is not set", name)
+		}
+
+		return envValue
+	})
+
+	return result, err
+}
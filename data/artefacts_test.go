@@ -0,0 +1,103 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"testing"
+
+	simpleyaml "github.com/essentialkaos/go-simpleyaml/v2"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestClone(t *testing.T) {
+	artefact := &Artefact{
+		Name:   "test",
+		Source: "test-{version}.tar.gz",
+		Test:   &ArtefactTest{Args: []string{"--version"}, Expect: "test {version}"},
+		Build: &ArtefactBuild{
+			Commands: []string{"make VERSION={version}"},
+			Env:      map[string]string{"CGO_ENABLED": "0"},
+		},
+	}
+
+	c := artefact.Clone()
+	c.ApplyVersion("1.0.0")
+	c.Test.Args[0] = "-v"
+	c.Build.Env["CGO_ENABLED"] = "1"
+
+	switch {
+	case artefact.Source != "test-{version}.tar.gz":
+		t.Errorf("Source of original artefact was modified: %q", artefact.Source)
+	case artefact.Test.Expect != "test {version}":
+		t.Errorf("Test.Expect of original artefact was modified: %q", artefact.Test.Expect)
+	case artefact.Test.Args[0] != "--version":
+		t.Errorf("Test.Args of original artefact was modified: %v", artefact.Test.Args)
+	case artefact.Build.Commands[0] != "make VERSION={version}":
+		t.Errorf("Build.Commands of original artefact was modified: %v", artefact.Build.Commands)
+	case artefact.Build.Env["CGO_ENABLED"] != "0":
+		t.Errorf("Build.Env of original artefact was modified: %v", artefact.Build.Env)
+	}
+
+	if c.Build.Commands[0] != "make VERSION=1.0.0" {
+		t.Errorf("Build.Commands of clone = %v, want version applied", c.Build.Commands)
+	}
+
+	if (*Artefact)(nil).Clone() != nil {
+		t.Errorf("Clone() of nil artefact must return nil")
+	}
+}
+
+func TestToYAML(t *testing.T) {
+	artefact := &Artefact{
+		Name:   "test: #1",
+		Repo:   "owner/repo",
+		Source: "test-*-linux.tar.gz",
+		OS:     DEFAULT_OS,
+	}
+
+	yaml, err := simpleyaml.NewYaml([]byte(artefact.toYAML("")))
+
+	if err != nil {
+		t.Fatalf("Can't parse YAML generated by toYAML(): %v", err)
+	}
+
+	item := yaml.GetByIndex(0)
+
+	for prop, value := range map[string]string{
+		"name":   artefact.Name,
+		"repo":   artefact.Repo,
+		"source": artefact.Source,
+	} {
+		if v := item.Get(prop).MustString(""); v != value {
+			t.Errorf("Property %q = %q, want %q", prop, v, value)
+		}
+	}
+}
+
+func TestValidateWithoutProvider(t *testing.T) {
+	artefact := &Artefact{
+		Name:   "fzf",
+		Repo:   "junegunn/fzf",
+		Source: "fzf-*-linux_amd64.tar.gz",
+		File:   "fzf",
+		Output: "fzf",
+	}
+
+	err := artefact.Validate()
+
+	if err != nil {
+		t.Errorf("Validate() for artefact without provider returned error: %v", err)
+	}
+
+	artefact.Provider = "unknown"
+
+	if artefact.Validate() == nil {
+		t.Errorf("Validate() for artefact with unknown provider must return error")
+	}
+}
@@ -8,38 +8,78 @@ package data
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/essentialkaos/ek/v13/fsutil"
-	"github.com/essentialkaos/ek/v13/jsonutil"
 	"github.com/essentialkaos/ek/v13/path"
 	"github.com/essentialkaos/ek/v13/sortutil"
+
+	"github.com/essentialkaos/artefactor/binary"
+	"github.com/essentialkaos/artefactor/vercmp"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// INDEX_SCHEMA is version of index schema
+const INDEX_SCHEMA = "2.4"
+
+// CATALOG_DIR is name of directory with catalog pages of artefacts
+const CATALOG_DIR = "catalog"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Index contains info about all stored artefacts
 type Index struct {
+	Schema    string          `json:"schema,omitempty"`
 	Artefacts []*ArtefactInfo `json:"artefacts"`
+
+	warnings []error
 }
 
 // ArtefactInfo contains info about artefact
 type ArtefactInfo struct {
 	Name     string             `json:"name"`
 	Versions []*ArtefactVersion `json:"versions"`
+	Channels map[string]string  `json:"channels,omitempty"`
+	Pin      *Pin               `json:"pin,omitempty"`
+	Ordering string             `json:"ordering,omitempty"`
 }
 
 // ArtefactVersion contains info about artefact version
 type ArtefactVersion struct {
-	Files   []string `json:"files"`
-	Version string   `json:"version"`
-	Size    int64    `json:"size"`
+	Files      []*ArtefactFile `json:"files"`
+	Version    string          `json:"version"`
+	Size       int64           `json:"size"`
+	Published  time.Time       `json:"published,omitzero"`
+	Downloaded time.Time       `json:"downloaded,omitzero"`
+	Repo       string          `json:"repo,omitempty"`
+	Tag        string          `json:"tag,omitempty"`
+	Asset      string          `json:"asset,omitempty"`
+	URL        string          `json:"url,omitempty"`
+}
+
+// ArtefactFile contains info about artefact version file
+type ArtefactFile struct {
+	Name     string       `json:"name"`
+	Size     int64        `json:"size"`
+	SHA256   string       `json:"sha256,omitempty"`
+	Platform string       `json:"platform,omitempty"`
+	Binary   *binary.Info `json:"binary,omitempty"`
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// BuildIndex builds index of artefacts
+// BuildIndex builds index of artefacts from scratch ignoring cached info
+// about files
 func BuildIndex(dir string) (*Index, error) {
 	err := fsutil.ValidatePerms("DRX", dir)
 
@@ -47,8 +87,11 @@ func BuildIndex(dir string) (*Index, error) {
 		return nil, err
 	}
 
-	index := &Index{}
-	dirs := fsutil.List(dir, true, fsutil.ListingFilter{Perms: "DRX"})
+	index := &Index{Schema: INDEX_SCHEMA}
+	dirs := fsutil.List(dir, true, fsutil.ListingFilter{
+		NotMatchPatterns: []string{CATALOG_DIR},
+		Perms:            "DRX",
+	})
 
 	if len(dirs) == 0 {
 		return nil, fmt.Errorf("Data directory is empty")
@@ -65,23 +108,22 @@ func BuildIndex(dir string) (*Index, error) {
 			continue
 		}
 
-		sortutil.Versions(versions)
-
 		info := &ArtefactInfo{Name: name}
 		index.Artefacts = append(index.Artefacts, info)
 
+		info.readState(path.Join(dir, name))
+
 		for _, version := range versions {
-			versionDir := path.Join(dir, name, version)
+			versionInfo, warn := getVersionInfo(path.Join(dir, name, version), version, false)
 
-			files := fsutil.List(versionDir, false, fsutil.ListingFilter{Perms: "FR"})
-			size := getVersionDataSize(versionDir, files)
+			if warn != nil {
+				index.warnings = append(index.warnings, fmt.Errorf("%s:%s: %v", name, version, warn))
+			}
 
-			info.Versions = append(info.Versions, &ArtefactVersion{
-				Version: version,
-				Files:   files,
-				Size:    size,
-			})
+			info.Versions = append(info.Versions, versionInfo)
 		}
+
+		info.sortVersions()
 	}
 
 	return index, nil
@@ -89,6 +131,124 @@ func BuildIndex(dir string) (*Index, error) {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// CheckSchema checks if index schema is supported
+func (i *Index) CheckSchema() error {
+	if i == nil || i.Schema == "" {
+		return nil
+	}
+
+	major, _, _ := strings.Cut(i.Schema, ".")
+	supportedMajor, _, _ := strings.Cut(INDEX_SCHEMA, ".")
+
+	majorVer, err := strconv.Atoi(major)
+
+	if err != nil {
+		return fmt.Errorf("Index has invalid schema version %q", i.Schema)
+	}
+
+	supportedMajorVer, _ := strconv.Atoi(supportedMajor)
+
+	if majorVer > supportedMajorVer {
+		return fmt.Errorf(
+			"Index uses schema %s, but only schema %s.x is supported. Update artefactor to the latest version.",
+			i.Schema, supportedMajor,
+		)
+	}
+
+	return nil
+}
+
+// AddVersion adds info about version stored in data directory to index or
+// updates it if version is already present in index
+func (i *Index) AddVersion(dir, name, version string) error {
+	if i == nil {
+		return fmt.Errorf("Index is nil")
+	}
+
+	versionDir := path.Join(dir, name, version)
+	err := fsutil.ValidatePerms("DRX", versionDir)
+
+	if err != nil {
+		return err
+	}
+
+	info := i.Find(name)
+
+	if info == nil {
+		info = &ArtefactInfo{Name: name}
+		i.Artefacts = append(i.Artefacts, info)
+		i.sortArtefacts()
+	}
+
+	info.readState(path.Join(dir, info.Name))
+
+	versionInfo, warn := getVersionInfo(versionDir, version, true)
+
+	if warn != nil {
+		i.warnings = append(i.warnings, fmt.Errorf("%s:%s: %v", name, version, warn))
+	}
+	index := slices.IndexFunc(info.Versions, func(v *ArtefactVersion) bool {
+		return v.Version == version
+	})
+
+	if index != -1 {
+		info.Versions[index] = versionInfo
+	} else {
+		info.Versions = append(info.Versions, versionInfo)
+	}
+
+	info.sortVersions()
+
+	return nil
+}
+
+// UpdateState updates artefact info using state from artefact directory
+func (i *Index) UpdateState(dir, name string) error {
+	info := i.Find(name)
+
+	if info == nil {
+		return fmt.Errorf("There is no artefact %q in index", name)
+	}
+
+	err := info.readState(path.Join(dir, info.Name))
+
+	if err != nil {
+		return err
+	}
+
+	info.sortVersions()
+
+	return nil
+}
+
+// RemoveVersion removes info about version from index
+func (i *Index) RemoveVersion(name, version string) {
+	info := i.Find(name)
+
+	if info == nil {
+		return
+	}
+
+	info.Versions = slices.DeleteFunc(info.Versions, func(v *ArtefactVersion) bool {
+		return v.Version == version
+	})
+
+	if len(info.Versions) == 0 {
+		i.Artefacts = slices.DeleteFunc(i.Artefacts, func(a *ArtefactInfo) bool {
+			return a == info
+		})
+	}
+}
+
+// Warnings returns non-fatal problems found while collecting info about versions
+func (i *Index) Warnings() []error {
+	if i == nil {
+		return nil
+	}
+
+	return i.warnings
+}
+
 // IsEmpty returns true if index is empty
 func (i *Index) IsEmpty() bool {
 	return i == nil || len(i.Artefacts) == 0
@@ -111,19 +271,37 @@ func (i *Index) Find(name string) *ArtefactInfo {
 	return nil
 }
 
-// Write writes index data into the file
-func (i *Index) Write(file string) error {
+// Write writes index data into the file. If key is set, detached signature is
+// written next to the index, otherwise stale signature is removed. Both files
+// are replaced atomically, signature goes first.
+func (i *Index) Write(file string, key ed25519.PrivateKey) error {
 	if i == nil {
 		return fmt.Errorf("Index is nil")
 	}
 
-	err := jsonutil.Write(file, i, 0644)
+	indexData, err := json.MarshalIndent(i, "", "  ")
 
 	if err != nil {
-		return err
+		return fmt.Errorf("Can't encode index: %v", err)
+	}
+
+	sigFile := file + SIGNATURE_EXT
+
+	if key != nil {
+		err = WriteFileAtomic(sigFile, SignData(indexData, key), 0644)
+
+		if err != nil {
+			return fmt.Errorf("Can't save signature: %v", err)
+		}
+	} else if fsutil.IsExist(sigFile) {
+		err = os.Remove(sigFile)
+
+		if err != nil {
+			return fmt.Errorf("Can't remove stale signature: %v", err)
+		}
 	}
 
-	return os.Chmod(file, 0644)
+	return WriteFileAtomic(file, indexData, 0644)
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -143,24 +321,260 @@ func (i *ArtefactInfo) Find(version string) *ArtefactVersion {
 	return nil
 }
 
-// Latest returns info about the latest version of artefact
+// Channel returns info about version in given release channel
+func (i *ArtefactInfo) Channel(channel string) *ArtefactVersion {
+	if i == nil || i.Channels[channel] == "" {
+		return nil
+	}
+
+	return i.Find(i.Channels[channel])
+}
+
+// Latest returns info about the latest version of artefact (or pinned version
+// if artefact is pinned)
 func (i *ArtefactInfo) Latest() *ArtefactVersion {
 	if i == nil || len(i.Versions) == 0 {
 		return nil
 	}
 
+	if i.Pin != nil && i.Find(i.Pin.Version) != nil {
+		return i.Find(i.Pin.Version)
+	}
+
 	return i.Versions[len(i.Versions)-1]
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// getVersionDataSize returns size of all version files
-func getVersionDataSize(versionDir string, files []string) int64 {
-	var result int64
+// Linkage returns linkage info for all version binaries
+func (v *ArtefactVersion) Linkage() string {
+	if v == nil {
+		return ""
+	}
+
+	var result []string
+
+	for _, file := range v.Files {
+		linkage := file.Binary.Linkage()
+
+		if linkage != "" && !slices.Contains(result, linkage) {
+			result = append(result, linkage)
+		}
+	}
+
+	return strings.Join(result, ", ")
+}
+
+// UnmarshalJSON decodes file info supporting indexes with schema 1.x where
+// files are stored as plain names
+func (f *ArtefactFile) UnmarshalJSON(data []byte) error {
+	if len(data) != 0 && data[0] == '"' {
+		return json.Unmarshal(data, &f.Name)
+	}
+
+	type artefactFile ArtefactFile
+
+	return json.Unmarshal(data, (*artefactFile)(f))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sortArtefacts sorts artefacts by name
+func (i *Index) sortArtefacts() {
+	var names []string
+
+	artefacts := map[string]*ArtefactInfo{}
+
+	for _, info := range i.Artefacts {
+		names = append(names, info.Name)
+		artefacts[info.Name] = info
+	}
+
+	sortutil.StringsNatural(names)
+
+	for j, name := range names {
+		i.Artefacts[j] = artefacts[name]
+	}
+}
+
+// readState reads state from artefact directory
+func (i *ArtefactInfo) readState(artefactDir string) error {
+	state, err := ReadState(artefactDir)
+
+	if err != nil {
+		return err
+	}
+
+	i.Channels = state.ChannelsVersions()
+	i.Pin = state.Pin
+	i.Ordering = state.Ordering
+
+	return nil
+}
+
+// sortVersions sorts artefact versions using artefact versions ordering
+func (i *ArtefactInfo) sortVersions() {
+	compareVersions := vercmp.Comparator(i.Ordering)
+
+	slices.SortStableFunc(i.Versions, func(v1, v2 *ArtefactVersion) int {
+		// Versions without publish date go first as one block ordered by
+		// version, so comparison stays transitive
+		if i.Ordering == vercmp.ORDERING_PUBLISH_DATE {
+			isUndated1, isUndated2 := v1.Published.IsZero(), v2.Published.IsZero()
+
+			switch {
+			case isUndated1 && !isUndated2:
+				return -1
+			case !isUndated1 && isUndated2:
+				return 1
+			case !v1.Published.Equal(v2.Published):
+				return v1.Published.Compare(v2.Published)
+			}
+		}
+
+		return compareVersions(v1.Version, v2.Version)
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getVersionInfo collects info about version stored in given directory. Returned
+// error is non-fatal and means that version metadata can't be read, so info
+// from it is missing.
+func getVersionInfo(versionDir, version string, useCache bool) (*ArtefactVersion, error) {
+	info := &ArtefactVersion{Version: version}
+	meta, err := ReadMetadata(versionDir)
+
+	// Broken metadata must be kept as is, so it will not be overwritten with
+	// files cache only
+	if err != nil {
+		meta, useCache = &Metadata{}, false
+	}
+
+	if meta.Release != nil {
+		info.Tag = meta.Release.Tag
+		info.Published = meta.Release.Published
+		info.URL = meta.Release.URL
+	}
+
+	if meta.Source != nil {
+		info.Repo = meta.Source.Repo
+		info.Asset = meta.Source.Asset
+		info.Downloaded = meta.Source.Downloaded
+	}
+
+	files := fsutil.List(versionDir, true, fsutil.ListingFilter{Perms: "FR"})
+
+	sortutil.StringsNatural(files)
+
+	var isCacheUpdated bool
 
 	for _, file := range files {
-		result += fsutil.GetSize(path.Join(versionDir, file))
+		filePath := path.Join(versionDir, file)
+		modTime, _ := fsutil.GetMTime(filePath)
+
+		var fileInfo *ArtefactFile
+
+		if useCache {
+			fileInfo = meta.GetFileInfo(file, fsutil.GetSize(filePath), modTime)
+		}
+
+		if fileInfo == nil {
+			fileInfo = getFileInfo(filePath, meta)
+			meta.SetFileInfo(file, modTime, fileInfo)
+			isCacheUpdated = true
+		}
+
+		info.Files = append(info.Files, fileInfo)
+		info.Size += fileInfo.Size
+	}
+
+	for file := range meta.Files {
+		if !slices.Contains(files, file) {
+			delete(meta.Files, file)
+			isCacheUpdated = true
+		}
+	}
+
+	if err == nil && isCacheUpdated && fsutil.CheckPerms("DW", versionDir) {
+		meta.Write(versionDir)
+	}
+
+	return info, err
+}
+
+// getFileInfo collects info about version file
+func getFileInfo(file string, meta *Metadata) *ArtefactFile {
+	info := &ArtefactFile{
+		Name:   path.Base(file),
+		Size:   fsutil.GetSize(file),
+		SHA256: getFileSHA256(file),
+	}
+
+	if binary.IsELF(file) {
+		binInfo, err := binary.Inspect(file)
+
+		if err == nil {
+			info.Binary = binInfo
+			info.Platform = "linux/" + binInfo.Arch
+		}
+	}
+
+	if info.Platform == "" && meta != nil && meta.Source != nil &&
+		meta.Source.File == info.Name {
+		info.Platform = meta.Source.Platform
+	}
+
+	return info
+}
+
+// getFileSHA256 returns SHA-256 checksum of given file
+func getFileSHA256(file string) string {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return ""
+	}
+
+	defer fd.Close()
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, fd)
+
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%x", hasher.Sum(nil))
+}
+
+// WriteFileAtomic writes data to temporary file in the same directory and
+// renames it to given file, so readers never see partially written file
+func WriteFileAtomic(file string, data []byte, perms os.FileMode) error {
+	fd, err := os.CreateTemp(path.Dir(file), "."+path.Base(file)+".*")
+
+	if err != nil {
+		return err
+	}
+
+	tmpFile := fd.Name()
+	_, err = fd.Write(data)
+
+	if err == nil {
+		err = fd.Chmod(perms)
+	}
+
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile, file)
+	}
+
+	if err != nil {
+		os.Remove(tmpFile)
 	}
 
-	return result
+	return err
 }
@@ -0,0 +1,87 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/artefactor/vercmp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestSortVersions(t *testing.T) {
+	d := time.Date(2024, 10, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		ordering string
+		versions []*ArtefactVersion
+		result   []string
+	}{
+		{
+			vercmp.ORDERING_SEMVER,
+			[]*ArtefactVersion{{Version: "2.0.0"}, {Version: "2.0.0-rc1"}, {Version: "1.10.0"}},
+			[]string{"1.10.0", "2.0.0-rc1", "2.0.0"},
+		},
+		{
+			vercmp.ORDERING_PUBLISH_DATE,
+			[]*ArtefactVersion{
+				{Version: "1.1.0", Published: d.AddDate(0, 0, -10)},
+				{Version: "1.0.5", Published: d},
+				{Version: "1.0.0", Published: d.AddDate(0, 0, -20)},
+			},
+			[]string{"1.0.0", "1.1.0", "1.0.5"},
+		},
+		{
+			// Undated versions go first as one block ordered by version
+			vercmp.ORDERING_PUBLISH_DATE,
+			[]*ArtefactVersion{
+				{Version: "3.0.0", Published: d.AddDate(0, 0, -10)},
+				{Version: "2.0.0"},
+				{Version: "1.0.0", Published: d},
+				{Version: "0.9.0"},
+				{Version: "2.5.0", Published: d},
+			},
+			[]string{"0.9.0", "2.0.0", "3.0.0", "1.0.0", "2.5.0"},
+		},
+	}
+
+	for _, test := range tests {
+		info := &ArtefactInfo{Name: "test", Ordering: test.ordering, Versions: test.versions}
+		info.sortVersions()
+
+		var result []string
+
+		for _, v := range info.Versions {
+			result = append(result, v.Version)
+		}
+
+		if !slices.Equal(result, test.result) {
+			t.Errorf("sortVersions() with %q ordering = %v, want %v", test.ordering, result, test.result)
+		}
+	}
+}
+
+func TestLatestPinned(t *testing.T) {
+	info := &ArtefactInfo{
+		Name:     "test",
+		Versions: []*ArtefactVersion{{Version: "1.0.0"}, {Version: "1.1.0"}},
+	}
+
+	if info.Latest().Version != "1.1.0" {
+		t.Errorf("Latest() = %q, want %q", info.Latest().Version, "1.1.0")
+	}
+
+	info.Pin = &Pin{Version: "1.0.0"}
+
+	if info.Latest().Version != "1.0.0" {
+		t.Errorf("Latest() with pin = %q, want %q", info.Latest().Version, "1.0.0")
+	}
+}
@@ -0,0 +1,166 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	simpleyaml "github.com/essentialkaos/go-simpleyaml/v2"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rootKeys is a list of supported keys on top level of sources file
+var rootKeys = []string{"include", "defaults", "templates", "artefacts"}
+
+// artefactKeys is a list of supported artefact properties
+var artefactKeys = []string{
+	"name", "repo", "provider", "output", "source", "file", "dir", "arch", "os", "token", "extend",
+	"mirror", "assets", "test", "actions", "build", "ordering",
+}
+
+// testKeys is a list of supported smoke test properties
+var testKeys = []string{"args", "expect", "timeout"}
+
+// actionsKeys is a list of supported GitHub Actions artifact properties
+var actionsKeys = []string{"workflow", "branch", "artifact", "version"}
+
+// buildKeys is a list of supported build recipe properties
+var buildKeys = []string{"commands", "env", "timeout"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LintArtefacts reads artefacts from given sources and returns all found problems
+func LintArtefacts(sources ...string) (Artefacts, []error) {
+	r, err := readSources(sources)
+
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	problems := r.problems
+
+	for _, artefact := range r.artefacts {
+		for _, problem := range artefact.Check() {
+			problems = append(problems, fmt.Errorf(
+				"%s: artefact %q: %s", artefact.Position(), artefact.Name, problem,
+			))
+		}
+	}
+
+	problems = append(problems, r.artefacts.findConflicts()...)
+
+	return r.artefacts, problems
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// lintDocument checks sources file for unknown keys
+func (r *sourcesReader) lintDocument(file string, data []byte, yaml *simpleyaml.Yaml, artefacts Artefacts) {
+	if yaml.IsMap() {
+		for _, key := range findUnknownKeys(yaml, rootKeys, "") {
+			r.addProblem(file, findKeyLine(data, 1, key), "unknown key %q", key)
+		}
+
+		if yaml.IsExist("defaults") {
+			line := findKeyLine(data, 1, "defaults")
+
+			for _, key := range findUnknownKeys(yaml.Get("defaults"), artefactKeys, "") {
+				r.addProblem(file, findKeyLine(data, line, key), "defaults: unknown key %q", key)
+			}
+		}
+
+		templates, _ := yaml.Get("templates").GetMapKeys()
+		line := findKeyLine(data, 1, "templates")
+
+		for _, name := range templates {
+			tmplLine := findKeyLine(data, line, name)
+
+			for _, key := range findUnknownKeys(yaml.Get("templates").Get(name), artefactKeys, "") {
+				r.addProblem(
+					file, findKeyLine(data, tmplLine, key),
+					"template %q: unknown key %q", name, key,
+				)
+			}
+		}
+	}
+
+	for _, artefact := range artefacts {
+		for _, key := range artefact.unknown {
+			_, subKey, _ := strings.Cut(key, ".")
+
+			r.addProblem(
+				file, findKeyLine(data, artefact.line, strutil.Q(subKey, key)),
+				"artefact %q: unknown key %q", artefact.Name, key,
+			)
+		}
+	}
+}
+
+// addProblem adds problem with position info
+func (r *sourcesReader) addProblem(file string, line int, format string, args ...any) {
+	pos := file
+
+	if line > 0 {
+		pos = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	r.problems = append(r.problems, fmt.Errorf(pos+": "+format, args...))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findUnknownKeys returns slice with keys which are not in the list of known keys
+func findUnknownKeys(yaml *simpleyaml.Yaml, known []string, prefix string) []string {
+	var result []string
+
+	if !yaml.IsMap() {
+		return nil
+	}
+
+	keys, _ := yaml.GetMapKeys()
+
+	for _, key := range keys {
+		if !slices.Contains(known, key) {
+			result = append(result, prefix+key)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// findKeyLine returns number of the first line starting from given one which
+// contains given key
+func findKeyLine(data []byte, from int, key string) int {
+	if from <= 0 {
+		return 0
+	}
+
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if i+1 < from {
+			continue
+		}
+
+		text := strings.TrimLeft(strings.TrimSpace(string(line)), "- ")
+
+		if strings.HasPrefix(text, key+":") ||
+			strings.HasPrefix(text, `"`+key+`":`) ||
+			strings.HasPrefix(text, "'"+key+"':") {
+			return i + 1
+		}
+	}
+
+	return from
+}
@@ -0,0 +1,136 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/jsonutil"
+	"github.com/essentialkaos/ek/v13/path"
+
+	"github.com/essentialkaos/artefactor/binary"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// METADATA_FILE is name of file with version metadata
+const METADATA_FILE = ".metadata.json"
+
+// BUILD_LOG_FILE is name of file with log of building artefact from source
+const BUILD_LOG_FILE = ".build.log"
+
+// RELEASE_FILE is name of file with original release info in mirrored versions
+const RELEASE_FILE = "release.json"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Metadata contains additional info about artefact version
+type Metadata struct {
+	Release *ReleaseInfo                 `json:"release,omitempty"`
+	Source  *SourceInfo                  `json:"source,omitempty"`
+	Build   map[string]*binary.BuildInfo `json:"build,omitempty"`
+	Files   map[string]*CachedFile       `json:"files,omitempty"`
+}
+
+// CachedFile contains cached info about version file
+type CachedFile struct {
+	Info    *ArtefactFile `json:"info"`
+	ModTime time.Time     `json:"mtime"`
+}
+
+// SourceInfo contains info about origin of downloaded data
+type SourceInfo struct {
+	Repo       string    `json:"repo"`
+	Asset      string    `json:"asset,omitempty"`
+	File       string    `json:"file,omitempty"`
+	Platform   string    `json:"platform,omitempty"`
+	Downloaded time.Time `json:"downloaded"`
+}
+
+// ReleaseInfo contains info about upstream release
+type ReleaseInfo struct {
+	Tag       string    `json:"tag"`
+	Name      string    `json:"name,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Published time.Time `json:"published"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadMetadata reads metadata from version directory
+func ReadMetadata(versionDir string) (*Metadata, error) {
+	metaFile := path.Join(versionDir, METADATA_FILE)
+	meta := &Metadata{}
+
+	if !fsutil.IsExist(metaFile) {
+		return meta, nil
+	}
+
+	err := jsonutil.Read(metaFile, meta)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read metadata: %v", err)
+	}
+
+	return meta, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes metadata into version directory
+func (m *Metadata) Write(versionDir string) error {
+	if m == nil {
+		return fmt.Errorf("Metadata is nil")
+	}
+
+	metaFile := path.Join(versionDir, METADATA_FILE)
+	err := jsonutil.Write(metaFile, m, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(metaFile, 0644)
+}
+
+// GetFileInfo returns cached info about given file if file wasn't modified
+func (m *Metadata) GetFileInfo(file string, size int64, modTime time.Time) *ArtefactFile {
+	if m == nil || m.Files[file] == nil {
+		return nil
+	}
+
+	cached := m.Files[file]
+
+	if cached.Info == nil || cached.Info.Size != size || !cached.ModTime.Equal(modTime) {
+		return nil
+	}
+
+	return cached.Info
+}
+
+// SetFileInfo caches info about given file
+func (m *Metadata) SetFileInfo(file string, modTime time.Time, info *ArtefactFile) {
+	if m.Files == nil {
+		m.Files = map[string]*CachedFile{}
+	}
+
+	m.Files[file] = &CachedFile{Info: info, ModTime: modTime}
+}
+
+// SetBuildInfo sets Go build info for given file
+func (m *Metadata) SetBuildInfo(file string, info *binary.BuildInfo) {
+	if m.Build == nil {
+		m.Build = map[string]*binary.BuildInfo{}
+	}
+
+	m.Build[file] = info
+}
@@ -0,0 +1,139 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/req"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SourcesToken is bearer token used for fetching remote sources files. Token
+// is sent only to hosts of sources files passed to ReadArtefacts.
+var SourcesToken string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readRemoteFile fetches remote sources file using cached copy if data wasn't
+// modified or remote server is unreachable
+func readRemoteFile(sourceURL, token string) ([]byte, error) {
+	cacheFile, etagFile := getRemoteCachePaths(sourceURL)
+	headers := req.Headers{}
+
+	if fsutil.IsExist(cacheFile) && fsutil.IsExist(etagFile) {
+		etag, err := os.ReadFile(etagFile)
+
+		if err == nil && len(etag) != 0 {
+			headers["If-None-Match"] = string(etag)
+		}
+	}
+
+	var auth req.Auth
+
+	if token != "" {
+		auth = req.AuthBearer{token}
+	}
+
+	resp, err := req.Request{
+		URL:         sourceURL,
+		Headers:     headers,
+		Auth:        auth,
+		AutoDiscard: true,
+	}.Get()
+
+	switch {
+	case err != nil, resp.StatusCode >= 500:
+		return readRemoteCache(sourceURL, cacheFile)
+	case resp.StatusCode == 304:
+		return readRemoteCache(sourceURL, cacheFile)
+	case resp.StatusCode != 200:
+		return nil, fmt.Errorf("Can't fetch %s: server returned status code %d", sourceURL, resp.StatusCode)
+	}
+
+	yamlData := resp.Bytes()
+
+	saveRemoteCache(cacheFile, etagFile, yamlData, resp.Header.Get("ETag"))
+
+	return yamlData, nil
+}
+
+// readRemoteCache reads cached copy of remote sources file
+func readRemoteCache(sourceURL, cacheFile string) ([]byte, error) {
+	yamlData, err := os.ReadFile(cacheFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch %s and there is no cached copy of it", sourceURL)
+	}
+
+	return yamlData, nil
+}
+
+// saveRemoteCache saves remote sources file and its ETag to cache directory
+func saveRemoteCache(cacheFile, etagFile string, yamlData []byte, etag string) {
+	if cacheFile == "" {
+		return
+	}
+
+	err := os.MkdirAll(path.Dir(cacheFile), 0700)
+
+	if err != nil {
+		return
+	}
+
+	err = os.WriteFile(cacheFile, yamlData, 0600)
+
+	if err != nil {
+		return
+	}
+
+	if etag == "" {
+		os.Remove(etagFile)
+		return
+	}
+
+	os.WriteFile(etagFile, []byte(etag), 0600)
+}
+
+// getRemoteCachePaths returns paths to cached copy of remote sources file and
+// its ETag
+func getRemoteCachePaths(sourceURL string) (string, string) {
+	cacheDir, err := os.UserCacheDir()
+
+	if err != nil {
+		return "", ""
+	}
+
+	name := fmt.Sprintf("%x", sha256.Sum256([]byte(sourceURL)))
+	cacheFile := path.Join(cacheDir, "artefactor", "sources", name+".yml")
+
+	return cacheFile, cacheFile + ".etag"
+}
+
+// resolveRemoteInclude resolves include path relative to remote sources file
+func resolveRemoteInclude(sourceURL, include string) string {
+	base, err := url.Parse(sourceURL)
+
+	if err != nil {
+		return include
+	}
+
+	ref, err := url.Parse(include)
+
+	if err != nil {
+		return include
+	}
+
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,127 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SIGNATURE_EXT is extension of detached signature files
+const SIGNATURE_EXT = ".sig"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SignData creates base64-encoded detached ed25519 signature for given data
+func SignData(data []byte, key ed25519.PrivateKey) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)) + "\n")
+}
+
+// VerifySignature verifies base64-encoded ed25519 signature of given data
+func VerifySignature(data, signature []byte, key ed25519.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+
+	if err != nil {
+		return fmt.Errorf("Signature has invalid format: %v", err)
+	}
+
+	if !ed25519.Verify(key, data, sig) {
+		return fmt.Errorf("Signature doesn't match data")
+	}
+
+	return nil
+}
+
+// ReadPrivateKey reads PEM-encoded (PKCS #8) ed25519 private key from file
+func ReadPrivateKey(file string) (ed25519.PrivateKey, error) {
+	keyData, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read private key: %v", err)
+	}
+
+	block, _ := pem.Decode(keyData)
+
+	if block == nil {
+		return nil, fmt.Errorf("Private key in %s is not PEM-encoded", file)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse private key: %v", err)
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+
+	if !ok {
+		return nil, fmt.Errorf("Private key in %s is not an ed25519 key", file)
+	}
+
+	return edKey, nil
+}
+
+// ParsePublicKey parses ed25519 public key from PEM-encoded (PKIX) file or
+// base64-encoded raw key
+func ParsePublicKey(key string) (ed25519.PublicKey, error) {
+	if fsutil.IsExist(key) {
+		return readPublicKeyFile(key)
+	}
+
+	keyData, err := base64.StdEncoding.DecodeString(key)
+
+	if err != nil {
+		return nil, fmt.Errorf("Public key is neither a file nor base64-encoded key")
+	}
+
+	if len(keyData) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("Public key has wrong size (%d ≠ %d)", len(keyData), ed25519.PublicKeySize)
+	}
+
+	return ed25519.PublicKey(keyData), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readPublicKeyFile reads PEM-encoded ed25519 public key from file
+func readPublicKeyFile(file string) (ed25519.PublicKey, error) {
+	keyData, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read public key: %v", err)
+	}
+
+	block, _ := pem.Decode(keyData)
+
+	if block == nil {
+		return nil, fmt.Errorf("Public key in %s is not PEM-encoded", file)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse public key: %v", err)
+	}
+
+	edKey, ok := key.(ed25519.PublicKey)
+
+	if !ok {
+		return nil, fmt.Errorf("Public key in %s is not an ed25519 key", file)
+	}
+
+	return edKey, nil
+}
@@ -0,0 +1,397 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/httputil"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/sortutil"
+
+	simpleyaml "github.com/essentialkaos/go-simpleyaml/v2"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sourcesReader reads artefacts from multiple sources
+type sourcesReader struct {
+	artefacts  Artefacts
+	problems   []error
+	visited    map[string]bool
+	tokenHosts map[string]bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadArtefacts reads YAML-encoded artefacts lists from given files and
+// directories
+func ReadArtefacts(sources ...string) (Artefacts, error) {
+	r, err := readSources(sources)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = errors.Join(r.artefacts.findConflicts()...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.artefacts, nil
+}
+
+// AppendArtefact appends artefact definition to the end of artefacts list in
+// given sources file keeping all existing content (including comments) untouched
+func AppendArtefact(file string, artefact *Artefact) error {
+	yamlData, err := os.ReadFile(file)
+
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Error while reading artefacts data: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(yamlData), "\n"), "\n")
+	pos, indent := findAppendPosition(lines)
+	entry := strings.Split(artefact.toYAML(strings.Repeat(" ", indent)), "\n")
+
+	if len(yamlData) == 0 {
+		lines = entry
+	} else {
+		lines = slices.Concat(lines[:pos], []string{""}, entry, lines[pos:])
+	}
+
+	result := []byte(strings.Join(lines, "\n") + "\n")
+
+	yaml, err := simpleyaml.NewYaml(result)
+
+	if err == nil {
+		_, err = convertArtefactsYaml(yaml, false)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Can't append artefact to %s: %v", file, err)
+	}
+
+	return os.WriteFile(file, result, 0644)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readSources reads artefacts from all given sources
+func readSources(sources []string) (*sourcesReader, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("No sources provided")
+	}
+
+	r := &sourcesReader{visited: map[string]bool{}, tokenHosts: map[string]bool{}}
+
+	// Token for remote sources is sent only to hosts of sources given by user
+	for _, source := range sources {
+		if httputil.IsURL(source) {
+			sourceURL, err := url.Parse(source)
+
+			if err == nil {
+				r.tokenHosts[sourceURL.Host] = true
+			}
+		}
+	}
+
+	for _, source := range sources {
+		err := r.readSource(source)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// readSource reads artefacts from file or directory with files
+func (r *sourcesReader) readSource(source string) error {
+	if httputil.IsURL(source) || !fsutil.IsDir(source) {
+		return r.readFile(source)
+	}
+
+	files := fsutil.List(source, true, fsutil.ListingFilter{
+		MatchPatterns: []string{"*.yml", "*.yaml"},
+		Perms:         "FR",
+	})
+
+	if len(files) == 0 {
+		return fmt.Errorf("Directory %s doesn't contain sources files", source)
+	}
+
+	sortutil.StringsNatural(files)
+
+	for _, file := range files {
+		err := r.readFile(path.Join(source, file))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readFile reads artefacts from file
+func (r *sourcesReader) readFile(file string) error {
+	var err error
+	var yamlData []byte
+
+	if !httputil.IsURL(file) {
+		file = path.Clean(file)
+	}
+
+	if r.visited[file] {
+		return nil
+	}
+
+	r.visited[file] = true
+
+	if httputil.IsURL(file) {
+		yamlData, err = r.readRemoteFile(file)
+	} else {
+		yamlData, err = os.ReadFile(file)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error while reading artefacts data: %v", err)
+	}
+
+	yaml, err := simpleyaml.NewYaml(yamlData)
+
+	if err != nil {
+		return fmt.Errorf("Error while parsing artefacts data from %s: %v", file, err)
+	}
+
+	for _, include := range getIncludes(yaml) {
+		switch {
+		case httputil.IsURL(file):
+			include = resolveRemoteInclude(file, include)
+		case !path.IsAbs(include) && !httputil.IsURL(include):
+			include = path.Join(path.Dir(file), include)
+		}
+
+		err = r.readSource(include)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	artefacts, err := convertArtefactsYaml(yaml, httputil.IsURL(file))
+
+	if err != nil {
+		return fmt.Errorf("Error while parsing artefacts data from %s: %v", file, err)
+	}
+
+	lines := findItemLines(yamlData)
+
+	for _, artefact := range artefacts {
+		artefact.file = file
+
+		if artefact.index < len(lines) {
+			artefact.line = lines[artefact.index]
+		}
+	}
+
+	r.lintDocument(file, yamlData, yaml, artefacts)
+	r.artefacts = append(r.artefacts, artefacts...)
+
+	return nil
+}
+
+// readRemoteFile fetches remote sources file over HTTPS
+func (r *sourcesReader) readRemoteFile(file string) ([]byte, error) {
+	fileURL, err := url.Parse(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid URL %q: %v", file, err)
+	}
+
+	if fileURL.Scheme != "https" {
+		return nil, fmt.Errorf("Remote sources file %s must be served over HTTPS", file)
+	}
+
+	var token string
+
+	if r.tokenHosts[fileURL.Host] {
+		token = SourcesToken
+	}
+
+	return readRemoteFile(file, token)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findConflicts checks artefacts for duplicate names, output files and
+// conflicting repository tokens
+func (a Artefacts) findConflicts() []error {
+	var errs []error
+
+	names := map[string]*Artefact{}
+	outputs := map[string]*Artefact{}
+	tokens := map[string]*Artefact{}
+
+	for _, artefact := range a {
+		if artefact.Name == "" {
+			continue
+		}
+
+		if names[artefact.Name] != nil {
+			errs = append(errs, fmt.Errorf(
+				"%s: artefact %q is already defined in %s",
+				artefact.Position(), artefact.Name, names[artefact.Name].Position(),
+			))
+		} else {
+			names[artefact.Name] = artefact
+		}
+
+		if artefact.Token != "" {
+			repo := strings.ToLower(artefact.Repo)
+
+			if tokens[repo] != nil && tokens[repo].Token != artefact.Token {
+				errs = append(errs, fmt.Errorf(
+					"%s: artefact %q uses different token for repository %s than artefact %q (%s)",
+					artefact.Position(), artefact.Name, artefact.Repo,
+					tokens[repo].Name, tokens[repo].Position(),
+				))
+			} else {
+				tokens[repo] = artefact
+			}
+		}
+
+		if artefact.Output == "" || artefact.IsMirror() {
+			continue
+		}
+
+		target := artefact.Target()
+
+		if outputs[target] != nil {
+			errs = append(errs, fmt.Errorf(
+				"%s: artefact %q has the same output %q as artefact %q (%s)",
+				artefact.Position(), artefact.Name, target,
+				outputs[target].Name, outputs[target].Position(),
+			))
+		} else {
+			outputs[target] = artefact
+		}
+	}
+
+	return errs
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getIncludes returns slice with included files and directories
+func getIncludes(yaml *simpleyaml.Yaml) []string {
+	if !yaml.IsMap() || !yaml.IsExist("include") {
+		return nil
+	}
+
+	include := yaml.Get("include")
+
+	if include.IsArray() {
+		return include.MustStringArray(nil)
+	}
+
+	return []string{include.MustString("")}
+}
+
+// findAppendPosition returns index of line after the last artefact definition
+// and indentation of artefacts list items
+func findAppendPosition(lines []string) (int, int) {
+	var section string
+	var pos, itemIndent int
+
+	for i, line := range lines {
+		text := strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		indent := len(text) - len(trimmed)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if indent == 0 && !strings.HasPrefix(trimmed, "-") && strings.Contains(trimmed, ":") {
+			section, _, _ = strings.Cut(trimmed, ":")
+
+			if section == "artefacts" {
+				pos, itemIndent = i+1, 2
+			}
+
+			continue
+		}
+
+		if section == "" || section == "artefacts" {
+			if strings.HasPrefix(trimmed, "- ") && (pos == 0 || indent <= itemIndent) {
+				itemIndent = indent
+			}
+
+			pos = i + 1
+		}
+	}
+
+	if pos == 0 {
+		pos = len(lines)
+	}
+
+	return pos, itemIndent
+}
+
+// findItemLines returns line numbers of all artefacts definitions
+func findItemLines(data []byte) []int {
+	var result []int
+	var section string
+
+	itemIndent := -1
+
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		text := strings.TrimRight(string(line), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		indent := len(text) - len(trimmed)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if indent == 0 && !strings.HasPrefix(trimmed, "-") && strings.Contains(trimmed, ":") {
+			section, _, _ = strings.Cut(trimmed, ":")
+			itemIndent = -1
+			continue
+		}
+
+		if section != "" && section != "artefacts" {
+			continue
+		}
+
+		if trimmed != "-" && !strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+
+		if itemIndent == -1 {
+			itemIndent = indent
+		}
+
+		if indent == itemIndent {
+			result = append(result, i+1)
+		}
+	}
+
+	return result
+}
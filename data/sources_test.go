@@ -0,0 +1,101 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	simpleyaml "github.com/essentialkaos/go-simpleyaml/v2"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestGetIncludes(t *testing.T) {
+	tests := []struct {
+		data   string
+		result []string
+	}{
+		{"include: common.yml\n", []string{"common.yml"}},
+		{"include:\n  - a.yml\n  - sources.d\n", []string{"a.yml", "sources.d"}},
+		{"artefacts:\n  - name: test\n", nil},
+		{"- name: test\n", nil},
+	}
+
+	for _, test := range tests {
+		yaml, err := simpleyaml.NewYaml([]byte(test.data))
+
+		if err != nil {
+			t.Fatalf("Can't parse YAML %q: %v", test.data, err)
+		}
+
+		result := getIncludes(yaml)
+
+		if !slices.Equal(result, test.result) {
+			t.Errorf("getIncludes(%q) = %v, want %v", test.data, result, test.result)
+		}
+	}
+}
+
+func TestConvertIncludeOnly(t *testing.T) {
+	yaml, err := simpleyaml.NewYaml([]byte("include:\n  - a.yml\n"))
+
+	if err != nil {
+		t.Fatalf("Can't parse YAML: %v", err)
+	}
+
+	artefacts, err := convertArtefactsYaml(yaml, false)
+
+	if err != nil {
+		t.Errorf("convertArtefactsYaml() returned error for include-only file: %v", err)
+	}
+
+	if len(artefacts) != 0 {
+		t.Errorf("convertArtefactsYaml() returned %d artefacts for include-only file", len(artefacts))
+	}
+}
+
+func TestReadArtefactsIncludes(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.yml": "include:\n  - a.yml\n  - b.yml\n",
+		"a.yml":    "artefacts:\n  - name: fzf\n    repo: junegunn/fzf\n    source: fzf-*-linux_amd64.tar.gz\n",
+		"b.yml":    "include: main.yml\n\nartefacts:\n  - name: yq\n    repo: mikefarah/yq\n    source: yq_linux_amd64\n",
+	}
+
+	for name, data := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644)
+
+		if err != nil {
+			t.Fatalf("Can't create sources file: %v", err)
+		}
+	}
+
+	artefacts, err := ReadArtefacts(filepath.Join(dir, "main.yml"))
+
+	if err != nil {
+		t.Fatalf("ReadArtefacts() returned error: %v", err)
+	}
+
+	var names []string
+
+	for _, artefact := range artefacts {
+		names = append(names, artefact.Name)
+	}
+
+	if !slices.Equal(names, []string{"fzf", "yq"}) {
+		t.Errorf("ReadArtefacts() returned artefacts %v, want [fzf yq]", names)
+	}
+
+	if artefacts[0].file != filepath.Join(dir, "a.yml") {
+		t.Errorf("Artefact %q has file %q, want %q", names[0], artefacts[0].file, filepath.Join(dir, "a.yml"))
+	}
+}
@@ -0,0 +1,131 @@
+package data
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/jsonutil"
+	"github.com/essentialkaos/ek/v13/path"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// STATE_FILE is name of file with artefact state
+const STATE_FILE = ".state.json"
+
+const (
+	CHANNEL_BETA   = "beta"
+	CHANNEL_STABLE = "stable"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// State contains artefact state which is managed by users
+type State struct {
+	Channels map[string]*Channel `json:"channels,omitempty"`
+	Pin      *Pin                `json:"pin,omitempty"`
+	Ordering string              `json:"ordering,omitempty"`
+}
+
+// Channel contains info about release channel
+type Channel struct {
+	Version string    `json:"version"`
+	Updated time.Time `json:"updated"`
+}
+
+// Pin contains info about pinned version
+type Pin struct {
+	Version string    `json:"version"`
+	Reason  string    `json:"reason,omitempty"`
+	Date    time.Time `json:"date"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadState reads state from artefact directory
+func ReadState(artefactDir string) (*State, error) {
+	stateFile := path.Join(artefactDir, STATE_FILE)
+	state := &State{}
+
+	if !fsutil.IsExist(stateFile) {
+		return state, nil
+	}
+
+	err := jsonutil.Read(stateFile, state)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read state: %v", err)
+	}
+
+	return state, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write writes state into artefact directory
+func (s *State) Write(artefactDir string) error {
+	if s == nil {
+		return fmt.Errorf("State is nil")
+	}
+
+	stateFile := path.Join(artefactDir, STATE_FILE)
+	err := jsonutil.Write(stateFile, s, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(stateFile, 0644)
+}
+
+// SetChannel points given channel to version
+func (s *State) SetChannel(channel, version string) {
+	if s.Channels == nil {
+		s.Channels = map[string]*Channel{}
+	}
+
+	s.Channels[channel] = &Channel{Version: version, Updated: time.Now().UTC()}
+}
+
+// SetPin pins artefact to given version
+func (s *State) SetPin(version, reason string) {
+	s.Pin = &Pin{Version: version, Reason: reason, Date: time.Now().UTC()}
+}
+
+// IsPinned returns true if artefact is pinned to some version
+func (s *State) IsPinned() bool {
+	return s != nil && s.Pin != nil
+}
+
+// GetChannel returns version for given channel
+func (s *State) GetChannel(channel string) string {
+	if s == nil || s.Channels[channel] == nil {
+		return ""
+	}
+
+	return s.Channels[channel].Version
+}
+
+// ChannelsVersions returns map channel → version
+func (s *State) ChannelsVersions() map[string]string {
+	if s == nil || len(s.Channels) == 0 {
+		return nil
+	}
+
+	result := map[string]string{}
+
+	for name, channel := range s.Channels {
+		result[name] = channel.Version
+	}
+
+	return result
+}
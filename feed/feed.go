@@ -0,0 +1,231 @@
+package feed
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/jsonutil"
+	"github.com/essentialkaos/ek/v13/path"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	CHANGES_FILE = "changes.json"
+	FEED_FILE    = "feed.atom"
+)
+
+// DEFAULT_SIZE is default maximum number of changes in feed
+const DEFAULT_SIZE = 50
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Change contains info about new artefact version
+type Change struct {
+	Artefact  string    `json:"artefact"`
+	Version   string    `json:"version"`
+	Repo      string    `json:"repo,omitempty"`
+	Tag       string    `json:"tag,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Size      int64     `json:"size"`
+	Published time.Time `json:"published,omitzero"`
+	Added     time.Time `json:"added"`
+}
+
+// Changes contains info about the latest changes (newest first)
+type Changes []*Change
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// atomFeed is Atom feed
+type atomFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Author  *atomAuthor  `xml:"author"`
+	Link    *atomLink    `xml:"link,omitempty"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+// atomAuthor is author of Atom feed
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// atomEntry is Atom feed entry
+type atomEntry struct {
+	ID      string    `xml:"id"`
+	Title   string    `xml:"title"`
+	Updated string    `xml:"updated"`
+	Link    *atomLink `xml:"link,omitempty"`
+	Summary string    `xml:"summary"`
+}
+
+// atomLink is link in Atom feed
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Update adds versions which are present in new index but absent in old one to
+// changes list and feed in data directory
+func Update(dir string, oldIndex, newIndex *data.Index, storage string, size int) error {
+	newChanges := Diff(oldIndex, newIndex)
+
+	if len(newChanges) == 0 {
+		return nil
+	}
+
+	changes, err := ReadChanges(path.Join(dir, CHANGES_FILE))
+
+	if err != nil {
+		return err
+	}
+
+	if size <= 0 {
+		size = DEFAULT_SIZE
+	}
+
+	changes = append(newChanges, changes...)
+	changes = changes[:min(len(changes), size)]
+
+	changesData, err := json.MarshalIndent(changes, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("Can't encode changes: %v", err)
+	}
+
+	err = data.WriteFileAtomic(path.Join(dir, CHANGES_FILE), changesData, 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't save changes: %v", err)
+	}
+
+	return changes.writeFeed(path.Join(dir, FEED_FILE), storage)
+}
+
+// Diff returns info about versions which are present in new index but absent
+// in old one
+func Diff(oldIndex, newIndex *data.Index) Changes {
+	var result Changes
+
+	// Without previous index all versions look new, so there is nothing to
+	// compare with
+	if oldIndex == nil || newIndex.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	for _, info := range newIndex.Artefacts {
+		oldInfo := oldIndex.Find(info.Name)
+
+		for _, version := range info.Versions {
+			if oldInfo.Find(version.Version) != nil {
+				continue
+			}
+
+			result = append(result, &Change{
+				Artefact:  info.Name,
+				Version:   version.Version,
+				Repo:      version.Repo,
+				Tag:       version.Tag,
+				URL:       version.URL,
+				Size:      version.Size,
+				Published: version.Published,
+				Added:     now,
+			})
+		}
+	}
+
+	return result
+}
+
+// ReadChanges reads changes from file
+func ReadChanges(file string) (Changes, error) {
+	var changes Changes
+
+	if !fsutil.IsExist(file) {
+		return nil, nil
+	}
+
+	err := jsonutil.Read(file, &changes)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read changes: %v", err)
+	}
+
+	return changes, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeFeed writes changes as Atom feed
+func (c Changes) writeFeed(file, storage string) error {
+	storage = strings.TrimRight(storage, "/")
+
+	if storage != "" && !strings.HasPrefix(storage, "http") {
+		storage = "https://" + storage
+	}
+
+	feed := &atomFeed{
+		ID:      "urn:artefactor:changes",
+		Title:   "New artefacts versions",
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Author:  &atomAuthor{Name: "artefactor"},
+	}
+
+	if storage != "" {
+		feed.ID = storage + "/" + FEED_FILE
+		feed.Link = &atomLink{Href: storage + "/", Rel: "alternate"}
+	}
+
+	for _, change := range c {
+		entry := &atomEntry{
+			ID:      fmt.Sprintf("urn:artefactor:%s:%s", change.Artefact, change.Version),
+			Title:   change.Artefact + " " + change.Version,
+			Updated: change.Added.UTC().Format(time.RFC3339),
+			Summary: fmt.Sprintf(
+				"%s %s (%s) added to storage",
+				change.Artefact, change.Version, fmtutil.PrettySize(change.Size),
+			),
+		}
+
+		if change.URL != "" {
+			entry.Link = &atomLink{Href: change.URL, Rel: "alternate"}
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	feedData, err := xml.MarshalIndent(feed, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("Can't encode feed: %v", err)
+	}
+
+	err = data.WriteFileAtomic(file, append([]byte(xml.Header), append(feedData, '\n')...), 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't save feed: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package feed
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/artefactor/data"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestDiff(t *testing.T) {
+	oldIndex := &data.Index{
+		Artefacts: []*data.ArtefactInfo{
+			{Name: "fzf", Versions: []*data.ArtefactVersion{{Version: "0.50.0"}}},
+			{Name: "yq", Versions: []*data.ArtefactVersion{{Version: "4.44.0"}}},
+		},
+	}
+
+	newIndex := &data.Index{
+		Artefacts: []*data.ArtefactInfo{
+			{
+				Name: "fzf",
+				Versions: []*data.ArtefactVersion{
+					{Version: "0.50.0"},
+					{Version: "0.55.0", Repo: "junegunn/fzf", Tag: "v0.55.0", Size: 100},
+				},
+			},
+			{Name: "shellcheck", Versions: []*data.ArtefactVersion{{Version: "0.10.0"}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		oldIndex *data.Index
+		newIndex *data.Index
+		result   []string
+	}{
+		{"new versions", oldIndex, newIndex, []string{"fzf:0.55.0", "shellcheck:0.10.0"}},
+		{"same index", newIndex, newIndex, nil},
+		{"removed versions", newIndex, oldIndex, []string{"yq:4.44.0"}},
+		{"no old index", nil, newIndex, nil},
+		{"empty new index", oldIndex, &data.Index{}, nil},
+	}
+
+	for _, test := range tests {
+		var result []string
+
+		for _, c := range Diff(test.oldIndex, test.newIndex) {
+			result = append(result, c.Artefact+":"+c.Version)
+
+			if c.Added.IsZero() {
+				t.Errorf("%s: change %s:%s has no added date", test.name, c.Artefact, c.Version)
+			}
+		}
+
+		if !slices.Equal(result, test.result) {
+			t.Errorf("%s: Diff() = %v, want %v", test.name, result, test.result)
+		}
+	}
+
+	changes := Diff(oldIndex, newIndex)
+
+	if changes[0].Repo != "junegunn/fzf" || changes[0].Tag != "v0.55.0" || changes[0].Size != 100 {
+		t.Errorf("Diff() returned change without version info: %+v", changes[0])
+	}
+}
+
+func TestWriteFeed(t *testing.T) {
+	file := filepath.Join(t.TempDir(), FEED_FILE)
+	changes := Changes{{Artefact: "fzf", Version: "0.55.0", Added: time.Now()}}
+
+	err := changes.writeFeed(file, "apps.example.com")
+
+	if err != nil {
+		t.Fatalf("writeFeed() returned error: %v", err)
+	}
+
+	feedData, err := os.ReadFile(file)
+
+	if err != nil {
+		t.Fatalf("Can't read feed: %v", err)
+	}
+
+	feed := &atomFeed{}
+	err = xml.Unmarshal(feedData, feed)
+
+	switch {
+	case err != nil:
+		t.Fatalf("Can't decode feed: %v", err)
+	case feed.Author == nil || feed.Author.Name != "artefactor":
+		t.Errorf("Feed has no author")
+	case feed.ID != "https://apps.example.com/"+FEED_FILE:
+		t.Errorf("Feed ID = %q, want %q", feed.ID, "https://apps.example.com/"+FEED_FILE)
+	case len(feed.Entries) != 1:
+		t.Errorf("Feed has %d entries, want 1", len(feed.Entries))
+	}
+}
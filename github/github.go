@@ -8,31 +8,82 @@ package github
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/essentialkaos/ek/v13/req"
 	"github.com/essentialkaos/ek/v13/timeutil"
+
+	"github.com/essentialkaos/artefactor/vercmp"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 const API_VERSION = "2022-11-28"
 
+const (
+	// TAGS_PER_PAGE is number of tags fetched with one request
+	TAGS_PER_PAGE = 100
+
+	// MAX_TAGS_PAGES is maximum number of fetched pages with tags
+	MAX_TAGS_PAGES = 50
+)
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Release contains info about release
 type Release struct {
 	Version     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Notes       string    `json:"body"`
+	URL         string    `json:"html_url"`
 	PublishDate time.Time `json:"published_at"`
 	Assets      []*Asset  `json:"assets"`
+
+	raw []byte
 }
 
 // Asset contains info about release asset
 type Asset struct {
-	URL string `json:"browser_download_url"`
+	Name   string `json:"name"`
+	URL    string `json:"browser_download_url"`
+	APIURL string `json:"url"`
+	Size   int64  `json:"size"`
+}
+
+// Tag contains info about git tag
+type Tag struct {
+	Name       string `json:"name"`
+	TarballURL string `json:"tarball_url"`
+	ZipballURL string `json:"zipball_url"`
+	Commit     struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// WorkflowRun contains info about GitHub Actions workflow run
+type WorkflowRun struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Number     int       `json:"run_number"`
+	HeadSHA    string    `json:"head_sha"`
+	HeadBranch string    `json:"head_branch"`
+	URL        string    `json:"html_url"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Artifact contains info about GitHub Actions workflow artifact
+type Artifact struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Size    int64  `json:"size_in_bytes"`
+	URL     string `json:"archive_download_url"`
+	Expired bool   `json:"expired"`
 }
 
 // Limits contains info about GitHubv API limits
@@ -47,11 +98,20 @@ type Limits struct {
 // Token is GitHub access token
 var Token string
 
+// repoTokens contains access tokens for specific repositories
+var repoTokens = map[string]string{}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // cache is cache for github releases data
 var cache = map[string]*Release{}
 
+// tagsCache is cache for github tags data
+var tagsCache = map[string][]*Tag{}
+
+// runsCache is cache for github workflow runs data
+var runsCache = map[string]*WorkflowRun{}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // GetLimits returns info about limits
@@ -89,6 +149,22 @@ func GetLimits() (Limits, error) {
 	}, nil
 }
 
+// SetRepoToken sets access token used for requests to given repository
+func SetRepoToken(repo, token string) {
+	repoTokens[strings.ToLower(repo)] = token
+}
+
+// GetToken returns access token for given repository
+func GetToken(repo string) string {
+	token := repoTokens[strings.ToLower(repo)]
+
+	if token != "" {
+		return token
+	}
+
+	return Token
+}
+
 // GetLatestReleaseVersion returns the latest version of release
 func GetLatestReleaseVersion(repo string) (string, time.Time, error) {
 	release, err := GetLatestReleaseInfo(repo)
@@ -123,28 +199,247 @@ func GetLatestReleaseInfo(repo string) (*Release, error) {
 		return cache[repo], nil
 	}
 
+	release := &Release{}
+	err := apiRequest(repo, "repos/"+repo+"/releases/latest", nil, release)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cache[repo] = release
+
+	return release, nil
+}
+
+// GetAssetDownloadURL returns URL for downloading release asset with given
+// browser URL. Browser URLs don't accept tokens, so assets of repositories
+// with access token are downloaded through API.
+func GetAssetDownloadURL(assetURL string) string {
+	repo, tag, name := parseAssetURL(assetURL)
+
+	if repo == "" || GetToken(repo) == "" {
+		return assetURL
+	}
+
+	release := cache[repo]
+
+	if release == nil || release.Version != tag {
+		release = &Release{}
+		err := apiRequest(repo, "repos/"+repo+"/releases/tags/"+url.PathEscape(tag), nil, release)
+
+		if err != nil {
+			return assetURL
+		}
+	}
+
+	for _, asset := range release.Assets {
+		if asset.Name == name && asset.APIURL != "" {
+			return asset.APIURL
+		}
+	}
+
+	return assetURL
+}
+
+// GetReleases returns info about recent releases
+func GetReleases(repo string, count int) ([]*Release, error) {
+	var releases []*Release
+
+	err := apiRequest(
+		repo,
+		"repos/"+repo+"/releases",
+		req.Query{"per_page": count},
+		&releases,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+// GetTags returns info about all repository tags
+func GetTags(repo string) ([]*Tag, error) {
+	if tagsCache[repo] != nil {
+		return tagsCache[repo], nil
+	}
+
+	var tags []*Tag
+
+	for page := 1; page <= MAX_TAGS_PAGES; page++ {
+		var pageTags []*Tag
+
+		err := apiRequest(
+			repo, "repos/"+repo+"/tags",
+			req.Query{"per_page": TAGS_PER_PAGE, "page": page},
+			&pageTags,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, pageTags...)
+
+		if len(pageTags) < TAGS_PER_PAGE {
+			break
+		}
+	}
+
+	tagsCache[repo] = tags
+
+	return tags, nil
+}
+
+// GetLatestTag returns tag with the latest version using given versions ordering.
+// Tags don't have publish date, so with publish-date ordering tags are compared
+// as semantic versions.
+func GetLatestTag(repo, ordering string) (*Tag, error) {
+	tags, err := GetTags(repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+
+	tagsIndex := map[string]*Tag{}
+
+	for _, tag := range tags {
+		version := tag.Version()
+
+		// Skip tags which don't look like versions
+		if version == "" || version[0] < '0' || version[0] > '9' {
+			continue
+		}
+
+		versions = append(versions, version)
+		tagsIndex[version] = tag
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("Repository %s has no version tags", repo)
+	}
+
+	return tagsIndex[vercmp.Latest(versions, ordering)], nil
+}
+
+// GetLatestWorkflowRun returns info about the latest successful run of workflow
+func GetLatestWorkflowRun(repo, workflow, branch string) (*WorkflowRun, error) {
+	cacheKey := repo + ":" + workflow + ":" + branch
+
+	if runsCache[cacheKey] != nil {
+		return runsCache[cacheKey], nil
+	}
+
+	query := req.Query{"status": "success", "per_page": 1}
+
+	if branch != "" {
+		query["branch"] = branch
+	}
+
+	runs := &struct {
+		Runs []*WorkflowRun `json:"workflow_runs"`
+	}{}
+
+	err := apiRequest(repo, "repos/"+repo+"/actions/workflows/"+workflow+"/runs", query, runs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runs.Runs) == 0 {
+		return nil, fmt.Errorf("Workflow %s in %s has no successful runs", workflow, repo)
+	}
+
+	runsCache[cacheKey] = runs.Runs[0]
+
+	return runs.Runs[0], nil
+}
+
+// GetRunArtifacts returns info about artifacts of workflow run
+func GetRunArtifacts(repo string, runID int64) ([]*Artifact, error) {
+	artifacts := &struct {
+		Artifacts []*Artifact `json:"artifacts"`
+	}{}
+
+	err := apiRequest(
+		repo,
+		fmt.Sprintf("repos/%s/actions/runs/%d/artifacts", repo, runID),
+		req.Query{"per_page": 100},
+		artifacts,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return artifacts.Artifacts, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Version returns version from tag name
+func (t *Tag) Version() string {
+	return strings.TrimLeft(t.Name, "v")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// UnmarshalJSON decodes release JSON keeping original data
+func (r *Release) UnmarshalJSON(data []byte) error {
+	type release Release
+
+	err := json.Unmarshal(data, (*release)(r))
+
+	if err != nil {
+		return err
+	}
+
+	r.raw = slices.Clone(data)
+
+	return nil
+}
+
+// Raw returns original release JSON returned by GitHub API
+func (r *Release) Raw() []byte {
+	if r == nil {
+		return nil
+	}
+
+	return r.raw
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// apiRequest sends request related to given repository to GitHub API and
+// decodes response
+func apiRequest(repo, endpoint string, query req.Query, result any) error {
 	headers := req.Headers{"X-GitHub-Api-Version": API_VERSION}
+	token := GetToken(repo)
 
-	if Token != "" {
-		headers["Authorization"] = "Bearer " + Token
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
 	}
 
 	resp, err := req.Request{
-		URL:         "https://api.github.com/repos/" + repo + "/releases/latest",
+		URL:         "https://api.github.com/" + endpoint,
+		Query:       query,
 		Accept:      "application/vnd.github+json",
 		Headers:     headers,
 		AutoDiscard: true,
 	}.Get()
 
 	if err != nil {
-		return nil, fmt.Errorf("Can't fetch GitHub data: %v", err)
+		return fmt.Errorf("Can't fetch GitHub data: %v", err)
 	}
 
 	if resp.Header.Get("X-Ratelimit-Remaining") == "0" {
 		resetTS, _ := strconv.ParseInt(resp.Header.Get("X-Ratelimit-Reset"), 10, 64)
 		resetDate := time.Unix(resetTS, 0)
 
-		return nil, fmt.Errorf(
+		return fmt.Errorf(
 			"Reached limit for requests to GitHub API (%s/%s | %s to reset)",
 			resp.Header.Get("X-Ratelimit-Used"),
 			resp.Header.Get("X-Ratelimit-Limit"),
@@ -153,17 +448,34 @@ func GetLatestReleaseInfo(repo string) (*Release, error) {
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitHub returned non-OK response code %d", resp.StatusCode)
+		return fmt.Errorf("GitHub returned non-OK response code %d", resp.StatusCode)
 	}
 
-	release := &Release{}
-	err = resp.JSON(release)
+	err = resp.JSON(result)
 
-	if err == nil {
-		cache[repo] = release
-	} else {
-		return nil, fmt.Errorf("Can't decode response JSON: %v", err)
+	if err != nil {
+		return fmt.Errorf("Can't decode response JSON: %v", err)
 	}
 
-	return release, nil
+	return nil
+}
+
+// parseAssetURL extracts repository, tag and asset name from browser URL of
+// release asset (https://github.com/owner/repo/releases/download/tag/name)
+func parseAssetURL(assetURL string) (string, string, string) {
+	u, err := url.Parse(assetURL)
+
+	if err != nil || u.Host != "github.com" {
+		return "", "", ""
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	if len(parts) < 6 || parts[2] != "releases" || parts[3] != "download" {
+		return "", "", ""
+	}
+
+	return parts[0] + "/" + parts[1],
+		strings.Join(parts[4:len(parts)-1], "/"),
+		parts[len(parts)-1]
 }
@@ -6,11 +6,11 @@ require (
 	github.com/essentialkaos/ek/v13 v13.35.1
 	github.com/essentialkaos/go-simpleyaml/v2 v2.2.0
 	github.com/essentialkaos/npck v1.7.3
+	github.com/essentialkaos/yaml/v2 v2.4.1
 )
 
 require (
 	github.com/essentialkaos/depsy v1.3.1 // indirect
-	github.com/essentialkaos/yaml/v2 v2.4.1 // indirect
 	github.com/klauspost/compress v1.18.0 // indirect
 	github.com/pierrec/lz4/v4 v4.1.22 // indirect
 	github.com/ulikunitz/xz v0.5.15 // indirect
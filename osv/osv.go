@@ -0,0 +1,217 @@
+package osv
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/jsonutil"
+	"github.com/essentialkaos/ek/v13/path"
+
+	"github.com/essentialkaos/artefactor/vercmp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ECOSYSTEM_GO = "Go"
+	RANGE_SEMVER = "SEMVER"
+)
+
+// STDLIB is name of Go standard library package in OSV database
+const STDLIB = "stdlib"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DB is vulnerability database
+type DB struct {
+	vulns map[string][]*Vuln
+}
+
+// Vuln contains info about vulnerability
+type Vuln struct {
+	ID       string      `json:"id"`
+	Summary  string      `json:"summary"`
+	Aliases  []string    `json:"aliases"`
+	Affected []*Affected `json:"affected"`
+}
+
+// Affected contains info about affected package
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []*Range `json:"ranges"`
+	Versions []string `json:"versions"`
+}
+
+// Package contains info about package
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// Range contains info about range of affected versions
+type Range struct {
+	Type   string   `json:"type"`
+	Events []*Event `json:"events"`
+}
+
+// Event contains info about range event
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Load loads all OSV records for Go ecosystem from given directory
+func Load(dir string) (*DB, error) {
+	err := fsutil.ValidatePerms("DRX", dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	db := &DB{vulns: map[string][]*Vuln{}}
+
+	files := fsutil.ListAllFiles(dir, true, fsutil.ListingFilter{
+		MatchPatterns: []string{"*.json"},
+	})
+
+	for _, file := range files {
+		vuln := &Vuln{}
+
+		// Database can contain non-OSV files (indexes, for example), so
+		// we just skip everything we can't decode
+		if jsonutil.Read(path.Join(dir, file), vuln) != nil || vuln.ID == "" {
+			continue
+		}
+
+		for _, affected := range vuln.Affected {
+			if affected.Package.Ecosystem != ECOSYSTEM_GO {
+				continue
+			}
+
+			name := affected.Package.Name
+
+			if !slices.Contains(db.vulns[name], vuln) {
+				db.vulns[name] = append(db.vulns[name], vuln)
+			}
+		}
+	}
+
+	if len(db.vulns) == 0 {
+		return nil, fmt.Errorf("Directory %s doesn't contain OSV records for Go modules", dir)
+	}
+
+	return db, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Find returns all vulnerabilities affecting given version of module
+func (db *DB) Find(module, version string) []*Vuln {
+	if db == nil || version == "" || version == "(devel)" {
+		return nil
+	}
+
+	var result []*Vuln
+
+	version = normalizeVersion(version)
+
+	for _, vuln := range db.vulns[module] {
+		if vuln.IsAffected(module, version) {
+			result = append(result, vuln)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsAffected returns true if given version of module is affected by vulnerability
+func (v *Vuln) IsAffected(module, version string) bool {
+	version = normalizeVersion(version)
+
+	for _, affected := range v.Affected {
+		if affected.Package.Ecosystem != ECOSYSTEM_GO || affected.Package.Name != module {
+			continue
+		}
+
+		if slices.Contains(affected.Versions, version) {
+			return true
+		}
+
+		for _, r := range affected.Ranges {
+			if r.Type == RANGE_SEMVER && r.Contains(version) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Fixed returns the first version with fix for given module
+func (v *Vuln) Fixed(module, version string) string {
+	version = normalizeVersion(version)
+
+	for _, affected := range v.Affected {
+		if affected.Package.Name != module {
+			continue
+		}
+
+		for _, r := range affected.Ranges {
+			for _, e := range r.Events {
+				if e.Fixed != "" && vercmp.Compare(e.Fixed, version) > 0 {
+					return e.Fixed
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// Contains returns true if given version is in range
+func (r *Range) Contains(version string) bool {
+	var isAffected bool
+
+	for _, e := range r.Events {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || vercmp.Compare(version, e.Introduced) >= 0 {
+				isAffected = true
+			}
+		case e.Fixed != "":
+			if vercmp.Compare(version, e.Fixed) >= 0 {
+				isAffected = false
+			}
+		case e.LastAffected != "":
+			if vercmp.Compare(version, e.LastAffected) > 0 {
+				isAffected = false
+			}
+		}
+	}
+
+	return isAffected
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// normalizeVersion converts Go module or toolchain version to OSV format
+func normalizeVersion(version string) string {
+	version = strings.TrimPrefix(version, "go")
+	version = strings.TrimPrefix(version, "v")
+
+	return strings.TrimSuffix(version, "+incompatible")
+}
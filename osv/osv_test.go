@@ -0,0 +1,116 @@
+package osv
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"testing"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		events  []*Event
+		version string
+		result  bool
+	}{
+		{[]*Event{{Introduced: "0"}}, "0.1.0", true},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0"}}, "1.1.9", true},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0"}}, "1.2.0", false},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0"}}, "1.10.0", false},
+		{[]*Event{{Introduced: "1.1.0"}, {Fixed: "1.2.0"}}, "1.0.9", false},
+		{[]*Event{{Introduced: "1.1.0"}, {Fixed: "1.2.0"}}, "1.1.0", true},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0-rc1"}}, "1.2.0-beta", true},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0-rc1"}}, "1.2.0", false},
+		{[]*Event{{Introduced: "0"}, {LastAffected: "1.2.0"}}, "1.2.0", true},
+		{[]*Event{{Introduced: "0"}, {LastAffected: "1.2.0"}}, "1.2.1", false},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0"}, {Introduced: "1.4.0"}, {Fixed: "1.6.0"}}, "1.0.0", true},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0"}, {Introduced: "1.4.0"}, {Fixed: "1.6.0"}}, "1.3.0", false},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0"}, {Introduced: "1.4.0"}, {Fixed: "1.6.0"}}, "1.5.0", true},
+		{[]*Event{{Introduced: "0"}, {Fixed: "1.2.0"}, {Introduced: "1.4.0"}, {Fixed: "1.6.0"}}, "1.6.0", false},
+		{nil, "1.0.0", false},
+	}
+
+	for _, test := range tests {
+		r := &Range{Type: RANGE_SEMVER, Events: test.events}
+
+		if r.Contains(test.version) != test.result {
+			t.Errorf("Range%v.Contains(%q) = %t, want %t", formatEvents(test.events), test.version, !test.result, test.result)
+		}
+	}
+}
+
+func TestVulnIsAffected(t *testing.T) {
+	vuln := &Vuln{
+		ID: "GO-2024-0001",
+		Affected: []*Affected{
+			{
+				Package: Package{Ecosystem: ECOSYSTEM_GO, Name: STDLIB},
+				Ranges: []*Range{{
+					Type:   RANGE_SEMVER,
+					Events: []*Event{{Introduced: "0"}, {Fixed: "1.22.5"}},
+				}},
+			},
+			{
+				Package:  Package{Ecosystem: ECOSYSTEM_GO, Name: "golang.org/x/net"},
+				Versions: []string{"0.20.0"},
+			},
+		},
+	}
+
+	tests := []struct {
+		module, version string
+		result          bool
+	}{
+		{STDLIB, "go1.22.4", true},
+		{STDLIB, "go1.22.5", false},
+		{STDLIB, "go1.23.0", false},
+		{"golang.org/x/net", "v0.20.0", true},
+		{"golang.org/x/net", "v0.21.0", false},
+		{"github.com/unknown/module", "v1.0.0", false},
+	}
+
+	for _, test := range tests {
+		if vuln.IsAffected(test.module, test.version) != test.result {
+			t.Errorf("IsAffected(%q, %q) = %t, want %t", test.module, test.version, !test.result, test.result)
+		}
+	}
+
+	if fixed := vuln.Fixed(STDLIB, "go1.22.4"); fixed != "1.22.5" {
+		t.Errorf("Fixed(%q, %q) = %q, want %q", STDLIB, "go1.22.4", fixed, "1.22.5")
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := map[string]string{
+		"go1.22.4":             "1.22.4",
+		"v1.2.3":               "1.2.3",
+		"v2.0.0+incompatible":  "2.0.0",
+		"1.0.0":                "1.0.0",
+		"v0.0.0-20240101-abcd": "0.0.0-20240101-abcd",
+	}
+
+	for version, result := range tests {
+		if v := normalizeVersion(version); v != result {
+			t.Errorf("normalizeVersion(%q) = %q, want %q", version, v, result)
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// formatEvents formats range events for error messages
+func formatEvents(events []*Event) []Event {
+	var result []Event
+
+	for _, e := range events {
+		result = append(result, *e)
+	}
+
+	return result
+}
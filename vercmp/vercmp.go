@@ -0,0 +1,270 @@
+package vercmp
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	ORDERING_SEMVER       = "semver"
+	ORDERING_CALVER       = "calver"
+	ORDERING_PUBLISH_DATE = "publish-date"
+)
+
+// DEFAULT_ORDERING is default versions ordering
+const DEFAULT_ORDERING = ORDERING_SEMVER
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Orderings is a list of supported versions orderings
+var Orderings = []string{ORDERING_SEMVER, ORDERING_CALVER, ORDERING_PUBLISH_DATE}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsValidOrdering returns true if given ordering is supported
+func IsValidOrdering(ordering string) bool {
+	return ordering == "" || slices.Contains(Orderings, ordering)
+}
+
+// Comparator returns function for comparing versions with given ordering.
+// Versions with publish-date ordering are compared as semantic versions,
+// because version name doesn't contain date.
+func Comparator(ordering string) func(v1, v2 string) int {
+	if ordering == ORDERING_CALVER {
+		return CompareCalver
+	}
+
+	return Compare
+}
+
+// Sort sorts versions in ascending order using given ordering
+func Sort(versions []string, ordering string) {
+	slices.SortStableFunc(versions, Comparator(ordering))
+}
+
+// Latest returns the latest version from given slice
+func Latest(versions []string, ordering string) string {
+	if len(versions) == 0 {
+		return ""
+	}
+
+	return slices.MaxFunc(versions, Comparator(ordering))
+}
+
+// Compare compares two semantic versions. Prerelease versions (1.0.0-rc1,
+// 1.0.0rc1) have lower precedence than release version. Leading "v" and build
+// metadata are ignored. Non-numeric parts are compared in natural order, so
+// versions like r9 and r10 are also ordered correctly.
+func Compare(v1, v2 string) int {
+	core1, pre1 := parseSemver(v1)
+	core2, pre2 := parseSemver(v2)
+
+	for i := range max(len(core1), len(core2)) {
+		p1, p2 := "0", "0"
+
+		if i < len(core1) {
+			p1 = core1[i]
+		}
+
+		if i < len(core2) {
+			p2 = core2[i]
+		}
+
+		if c := compareIdentifiers(p1, p2); c != 0 {
+			return c
+		}
+	}
+
+	return comparePrerelease(pre1, pre2)
+}
+
+// CompareCalver compares two calendar versions (2024.10.09, 24.04.1, 2024-10-09).
+// Version with additional parts is newer (2024.10.09-1 is newer than 2024.10.09).
+func CompareCalver(v1, v2 string) int {
+	p1, p2 := splitCalver(v1), splitCalver(v2)
+
+	for i := range min(len(p1), len(p2)) {
+		if c := compareIdentifiers(p1[i], p2[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(p1), len(p2))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseSemver splits version into core parts and prerelease part
+func parseSemver(v string) ([]string, string) {
+	if len(v) > 1 && (v[0] == 'v' || v[0] == 'V') && isDigit(v[1]) {
+		v = v[1:]
+	}
+
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+
+	core := strings.Split(v, ".")
+	last := core[len(core)-1]
+
+	// Prerelease without separator (1.0.0rc1)
+	if last != "" && isDigit(last[0]) {
+		if i := strings.IndexFunc(last, isLetter); i != -1 {
+			core[len(core)-1] = last[:i]
+			pre = strings.Trim(last[i:]+"-"+pre, "-")
+		}
+	}
+
+	return core, pre
+}
+
+// splitCalver splits calendar version into parts
+func splitCalver(v string) []string {
+	if len(v) > 1 && (v[0] == 'v' || v[0] == 'V') && isDigit(v[1]) {
+		v = v[1:]
+	}
+
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_' || r == '+'
+	})
+}
+
+// comparePrerelease compares prerelease parts of semantic versions
+func comparePrerelease(pre1, pre2 string) int {
+	switch {
+	case pre1 == pre2:
+		return 0
+	case pre1 == "":
+		return 1
+	case pre2 == "":
+		return -1
+	}
+
+	ids1, ids2 := splitPrerelease(pre1), splitPrerelease(pre2)
+
+	for i := range min(len(ids1), len(ids2)) {
+		isNum1, isNum2 := isNumber(ids1[i]), isNumber(ids2[i])
+
+		switch {
+		case isNum1 && !isNum2:
+			return -1
+		case !isNum1 && isNum2:
+			return 1
+		}
+
+		if c := compareIdentifiers(ids1[i], ids2[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(ids1), len(ids2))
+}
+
+// splitPrerelease splits prerelease part into identifiers. Identifiers like
+// rc1 are split into rc and 1, so 1.22rc1 and 1.22.0-rc.1 are equal.
+func splitPrerelease(pre string) []string {
+	var result []string
+
+	for _, id := range strings.Split(pre, ".") {
+		i := strings.IndexAny(id, "0123456789")
+
+		if i > 0 && isWord(id[:i]) && isNumber(id[i:]) {
+			result = append(result, id[:i], id[i:])
+		} else {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// compareIdentifiers compares two version parts numerically if both are numbers
+// or in natural order otherwise
+func compareIdentifiers(s1, s2 string) int {
+	if isNumber(s1) && isNumber(s2) {
+		return compareNumbers(s1, s2)
+	}
+
+	for s1 != "" && s2 != "" {
+		c1, c2 := nextChunk(s1), nextChunk(s2)
+
+		var c int
+
+		if isDigit(c1[0]) && isDigit(c2[0]) {
+			c = compareNumbers(c1, c2)
+		} else {
+			c = strings.Compare(c1, c2)
+		}
+
+		if c != 0 {
+			return c
+		}
+
+		s1, s2 = s1[len(c1):], s2[len(c2):]
+	}
+
+	return compareInts(len(s1), len(s2))
+}
+
+// nextChunk returns leading sequence of digits or non-digits
+func nextChunk(s string) string {
+	i := 1
+
+	for i < len(s) && isDigit(s[i]) == isDigit(s[0]) {
+		i++
+	}
+
+	return s[:i]
+}
+
+// compareNumbers compares two numbers of any length
+func compareNumbers(n1, n2 string) int {
+	n1 = strings.TrimLeft(n1, "0")
+	n2 = strings.TrimLeft(n2, "0")
+
+	if len(n1) != len(n2) {
+		return compareInts(len(n1), len(n2))
+	}
+
+	return strings.Compare(n1, n2)
+}
+
+// compareInts compares two integers
+func compareInts(i1, i2 int) int {
+	switch {
+	case i1 < i2:
+		return -1
+	case i1 > i2:
+		return 1
+	}
+
+	return 0
+}
+
+// isNumber returns true if given string contains only digits
+func isNumber(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// isWord returns true if given string contains only latin letters
+func isWord(s string) bool {
+	return s != "" && strings.TrimFunc(s, isLetter) == ""
+}
+
+// isDigit returns true if given byte is a digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isLetter returns true if given rune is a latin letter
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
@@ -0,0 +1,150 @@
+package vercmp
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"testing"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		v1, v2 string
+		result int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "v1.0.0", 0},
+		{"1.0", "1.0.0", 0},
+		{"1.0.0+build.1", "1.0.0", 0},
+		{"1.9.0", "1.10.0", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"2.0.0-rc1", "2.0.0", -1},
+		{"2.0.0", "2.0.0-rc1", 1},
+		{"2.0.0-rc.2", "2.0.0-rc.10", -1},
+		{"2.0.0-rc1", "2.0.0-rc10", -1},
+		{"2.0.0-beta", "2.0.0-rc1", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0rc1", "1.0.0", -1},
+		{"1.0.0rc1", "1.0.0-rc1", 0},
+		{"1.0.0rc1", "1.0.0rc2", -1},
+		{"1.22rc1", "1.22.0-rc.1", 0},
+		{"1.22rc1", "1.22.0", -1},
+		{"1.22rc2", "1.22rc1", 1},
+		{"2024.10.09", "2024.9.30", 1},
+		{"r36", "r4", 1},
+		{"r9", "r10", -1},
+		{"r36", "r36", 0},
+	}
+
+	for _, test := range tests {
+		result := Compare(test.v1, test.v2)
+
+		if result != test.result {
+			t.Errorf("Compare(%q, %q) = %d, want %d", test.v1, test.v2, result, test.result)
+		}
+
+		// Comparison must be antisymmetric
+		if reverse := Compare(test.v2, test.v1); reverse != -test.result {
+			t.Errorf("Compare(%q, %q) = %d, want %d", test.v2, test.v1, reverse, -test.result)
+		}
+	}
+}
+
+func TestCompareCalver(t *testing.T) {
+	tests := []struct {
+		v1, v2 string
+		result int
+	}{
+		{"2024.10.09", "2024.10.09", 0},
+		{"2024.10.09", "v2024.10.09", 0},
+		{"2024.10.09", "2024.9.30", 1},
+		{"2024.10.09", "2024.10.10", -1},
+		{"2024.10.09-1", "2024.10.09", 1},
+		{"2024.10.09-2", "2024.10.09-1", 1},
+		{"24.04", "24.04.1", -1},
+		{"2024-10-09", "2024.10.09", 0},
+	}
+
+	for _, test := range tests {
+		result := CompareCalver(test.v1, test.v2)
+
+		if result != test.result {
+			t.Errorf("CompareCalver(%q, %q) = %d, want %d", test.v1, test.v2, result, test.result)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		ordering string
+		versions []string
+		result   []string
+	}{
+		{
+			ORDERING_SEMVER,
+			[]string{"2.0.0", "2.0.0-rc1", "1.10.0", "1.9.0", "2.0.0-beta"},
+			[]string{"1.9.0", "1.10.0", "2.0.0-beta", "2.0.0-rc1", "2.0.0"},
+		},
+		{
+			ORDERING_SEMVER,
+			[]string{"r36", "r4", "r100", "r10"},
+			[]string{"r4", "r10", "r36", "r100"},
+		},
+		{
+			ORDERING_CALVER,
+			[]string{"2024.10.09-1", "2024.9.30", "2024.10.09", "2023.12.31"},
+			[]string{"2023.12.31", "2024.9.30", "2024.10.09", "2024.10.09-1"},
+		},
+	}
+
+	for _, test := range tests {
+		versions := slices.Clone(test.versions)
+		Sort(versions, test.ordering)
+
+		if !slices.Equal(versions, test.result) {
+			t.Errorf("Sort(%v, %q) = %v, want %v", test.versions, test.ordering, versions, test.result)
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		ordering string
+		versions []string
+		result   string
+	}{
+		{ORDERING_SEMVER, nil, ""},
+		{ORDERING_SEMVER, []string{"2.0.0-rc1", "2.0.0", "1.9.0"}, "2.0.0"},
+		{ORDERING_SEMVER, []string{"2.1.0-rc1", "2.0.0"}, "2.1.0-rc1"},
+		{ORDERING_PUBLISH_DATE, []string{"1.0.0", "1.1.0"}, "1.1.0"},
+		{ORDERING_CALVER, []string{"2024.10.09", "2024.10.09-1"}, "2024.10.09-1"},
+	}
+
+	for _, test := range tests {
+		result := Latest(test.versions, test.ordering)
+
+		if result != test.result {
+			t.Errorf("Latest(%v, %q) = %q, want %q", test.versions, test.ordering, result, test.result)
+		}
+	}
+}
+
+func TestIsValidOrdering(t *testing.T) {
+	for _, ordering := range []string{"", ORDERING_SEMVER, ORDERING_CALVER, ORDERING_PUBLISH_DATE} {
+		if !IsValidOrdering(ordering) {
+			t.Errorf("IsValidOrdering(%q) = false, want true", ordering)
+		}
+	}
+
+	if IsValidOrdering("date") {
+		t.Errorf("IsValidOrdering(%q) = true, want false", "date")
+	}
+}